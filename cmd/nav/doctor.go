@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	grovelogging "github.com/grovetools/core/logging"
+	tablecomponent "github.com/grovetools/core/tui/components/table"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var ulogDoctor = grovelogging.NewUnifiedLogger("nav.doctor")
+
+var doctorJSON bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that nav's tmux hotkeys are wired up",
+	Long: `Check the running tmux server for the usual reasons a nav hotkey does
+nothing:
+
+  bindings          the generated bindings are loaded (tmux list-keys)
+  prefix-shadowing  no root or prefix binding overrides a nav prefix
+  history-hook      nav's session-switch and session-end hooks are installed
+  mapped-paths      every mapped path exists
+  daemon            the grove daemon is reachable
+  tmux-socket       GROVE_TMUX_SOCKET matches the current tmux server
+  nav-binary        the nav in the grove bin directory is the one running
+
+Exits non-zero when any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		checks := mgr.Doctor()
+
+		failed := 0
+		for _, c := range checks {
+			if c.Status == manager.DoctorFail {
+				failed++
+			}
+		}
+
+		if doctorJSON {
+			data, err := json.MarshalIndent(checks, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal doctor results to JSON: %w", err)
+			}
+			ulogDoctor.Info("Doctor").
+				Field("format", "json").
+				Field("failed_count", failed).
+				Pretty(string(data)).
+				PrettyOnly().
+				Emit()
+		} else {
+			reportDoctor(checks, failed)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d doctor check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// reportDoctor prints the checks as a table followed by a summary line.
+func reportDoctor(checks []manager.DoctorCheck, failed int) {
+	var rows [][]string
+	for _, c := range checks {
+		var status string
+		switch c.Status {
+		case manager.DoctorPass:
+			status = theme.DefaultTheme.Success.Render(theme.IconSuccess + " pass")
+		case manager.DoctorWarn:
+			status = theme.DefaultTheme.Warning.Render(theme.IconWarning + " warn")
+		default:
+			status = theme.DefaultTheme.Error.Render(theme.IconError + " fail")
+		}
+		message := c.Message
+		if len(c.Details) > 0 {
+			message += "\n  " + strings.Join(c.Details, "\n  ")
+		}
+		rows = append(rows, []string{
+			theme.DefaultTheme.Highlight.Render(c.Name),
+			status,
+			message,
+		})
+	}
+	t := tablecomponent.NewStyledTable().
+		Headers("Check", "Status", "Details").
+		Rows(rows...)
+
+	summary := fmt.Sprintf("%s All checks passed", theme.IconSuccess)
+	if failed > 0 {
+		summary = fmt.Sprintf("%s %d check(s) failed", theme.IconError, failed)
+	}
+	ulogDoctor.Info("Doctor").
+		Field("check_count", len(checks)).
+		Field("failed_count", failed).
+		Pretty(t.String() + "\n" + summary).
+		PrettyOnly().
+		Emit()
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output the checks as JSON")
+
+	rootCmd.AddCommand(doctorCmd)
+}
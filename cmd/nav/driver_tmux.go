@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	tmuxclient "github.com/grovetools/core/pkg/tmux"
 
+	"github.com/grovetools/core/tui/theme"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/api"
 	"github.com/grovetools/nav/pkg/tmux"
 	"github.com/grovetools/nav/pkg/tui/keymanage"
 	"github.com/grovetools/nav/pkg/tui/sessionizer"
@@ -26,11 +32,14 @@ var (
 // will provide its own driver when embedding the sessionizer.
 type TmuxDriver struct {
 	client *tmuxclient.Client
+	mgr    *tmux.Manager
 }
 
 // NewTmuxDriver constructs a TmuxDriver. The caller owns client lifetime.
-func NewTmuxDriver(client *tmuxclient.Client) *TmuxDriver {
-	return &TmuxDriver{client: client}
+// mgr is optional; when set, new sessions are created through it so
+// configured layouts are applied, and lifecycle hooks are run.
+func NewTmuxDriver(client *tmuxclient.Client, mgr *tmux.Manager) *TmuxDriver {
+	return &TmuxDriver{client: client, mgr: mgr}
 }
 
 // Compile-time checks that TmuxDriver satisfies the sessionizer +
@@ -41,24 +50,99 @@ var (
 	_ sessionizer.SessionDriver        = (*TmuxDriver)(nil)
 	_ sessionizer.SessionStateProvider = (*TmuxDriver)(nil)
 	_ keymanage.SessionDriver          = (*TmuxDriver)(nil)
+	_ api.SessionPathLister            = (*TmuxDriver)(nil)
 )
 
-// Launch starts a new tmux session with the given name and working directory.
+// Launch starts a new tmux session with the given name and working
+// directory, then starts its on_create hooks in the background.
 func (d *TmuxDriver) Launch(ctx context.Context, sessionName, workingDir string) error {
-	return d.client.Launch(ctx, tmuxclient.LaunchOptions{
-		SessionName:      sessionName,
-		WorkingDirectory: workingDir,
-	})
+	if d.mgr == nil {
+		return d.client.Launch(ctx, tmuxclient.LaunchOptions{
+			SessionName:      sessionName,
+			WorkingDirectory: workingDir,
+		})
+	}
+	if err := d.mgr.LaunchSession(ctx, sessionName, workingDir); err != nil {
+		return err
+	}
+	d.runHooks(manager.HookCreate, sessionName, workingDir)
+	return nil
 }
 
-// SwitchTo switches the current tmux client to the given session.
+// SwitchTo switches the current tmux client to the given session, then
+// starts its on_switch hooks in the background.
 func (d *TmuxDriver) SwitchTo(ctx context.Context, sessionName string) error {
-	return d.client.SwitchClientToSession(ctx, sessionName)
+	if err := d.client.SwitchClientToSession(ctx, sessionName); err != nil {
+		return err
+	}
+	d.runHooks(manager.HookSwitch, sessionName, "")
+	return nil
 }
 
-// Kill destroys the given tmux session.
+// Kill destroys the given tmux session, then starts its on_kill hooks. The
+// session path is read first since it is gone once the session is.
 func (d *TmuxDriver) Kill(ctx context.Context, sessionName string) error {
-	return d.client.KillSession(ctx, sessionName)
+	path := manager.SessionPath(sessionName)
+	if err := d.client.KillSession(ctx, sessionName); err != nil {
+		return err
+	}
+	d.runHooks(manager.HookKill, sessionName, path)
+	return nil
+}
+
+// tuiHooks tracks the hooks TmuxDrivers run in the background, so the TUI
+// never waits on them but the process does before it exits. last is done
+// once the most recently started hooks have finished; each run waits for it
+// so hooks keep their order (on_create before on_switch).
+var tuiHooks struct {
+	sync.Mutex
+	wg   sync.WaitGroup
+	last chan struct{}
+}
+
+// runHooks resolves the hooks for event and runs them in the background.
+// The TUI's context ends with the TUI, so they run under their own.
+func (d *TmuxDriver) runHooks(event manager.HookEvent, sessionName, path string) {
+	if d.mgr == nil {
+		return
+	}
+	run := d.mgr.PrepareHooks(event, sessionName, path, true)
+
+	tuiHooks.Lock()
+	prev := tuiHooks.last
+	done := make(chan struct{})
+	tuiHooks.last = done
+	tuiHooks.wg.Add(1)
+	tuiHooks.Unlock()
+
+	go func() {
+		defer tuiHooks.wg.Done()
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		run(context.Background())
+	}()
+}
+
+// waitForTUIHooks blocks until the hooks started from the TUI have
+// finished, telling the user why the exit is delayed if they have not.
+func waitForTUIHooks() {
+	done := make(chan struct{})
+	go func() {
+		tuiHooks.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return
+	case <-time.After(200 * time.Millisecond):
+	}
+	ulogSession.Info("Waiting for session hooks").
+		Pretty(theme.IconInfo + " Waiting for session hooks to finish...").
+		PrettyOnly().
+		Emit()
+	<-done
 }
 
 // ClosePopup dismisses the tmux popup the TUI is running inside, if any.
@@ -74,6 +158,11 @@ func (d *TmuxDriver) ListActive(ctx context.Context) ([]string, error) {
 	return d.client.ListSessions(ctx)
 }
 
+// ListActivePaths maps each running tmux session to its root directory.
+func (d *TmuxDriver) ListActivePaths(ctx context.Context) (map[string]string, error) {
+	return manager.RunningSessionPaths(), nil
+}
+
 // Exists reports whether a session with the given name exists.
 func (d *TmuxDriver) Exists(ctx context.Context, sessionName string) (bool, error) {
 	return d.client.SessionExists(ctx, sessionName)
@@ -6,6 +6,8 @@ import (
 	"github.com/grovetools/core/pkg/mux"
 	tmuxclient "github.com/grovetools/core/pkg/tmux"
 
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/api"
 	"github.com/grovetools/nav/pkg/tui/keymanage"
 	"github.com/grovetools/nav/pkg/tui/sessionizer"
 	"github.com/grovetools/nav/pkg/tui/windows"
@@ -25,6 +27,7 @@ var (
 	_ sessionizer.SessionDriver        = (*TuimuxDriver)(nil)
 	_ sessionizer.SessionStateProvider = (*TuimuxDriver)(nil)
 	_ keymanage.SessionDriver          = (*TuimuxDriver)(nil)
+	_ api.SessionPathLister            = (*TuimuxDriver)(nil)
 )
 
 func (d *TuimuxDriver) Launch(ctx context.Context, sessionName, workingDir string) error {
@@ -57,6 +60,11 @@ func (d *TuimuxDriver) ListActive(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// ListActivePaths maps each running tuimux session to its root directory.
+func (d *TuimuxDriver) ListActivePaths(ctx context.Context) (map[string]string, error) {
+	return manager.EngineSessionPaths(ctx, d.engine)
+}
+
 func (d *TuimuxDriver) Exists(ctx context.Context, sessionName string) (bool, error) {
 	return d.engine.SessionExists(ctx, sessionName)
 }
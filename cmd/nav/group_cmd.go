@@ -2,19 +2,23 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	core_theme "github.com/grovetools/core/tui/theme"
 	"github.com/spf13/cobra"
 
+	navbindings "github.com/grovetools/nav/pkg/bindings"
 	"github.com/grovetools/nav/pkg/tmux"
 )
 
 var (
 	groupPrefix string
 	forceDelete bool
+	groupJSON   bool
 )
 
 var groupCmd = &cobra.Command{
@@ -31,6 +35,9 @@ var groupListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if groupJSON {
+			return printGroupJSON(mgr.GroupInfos())
+		}
 		for _, g := range mgr.GetAllGroups() {
 			active := ""
 			if g != "default" {
@@ -51,6 +58,9 @@ var groupCreateCmd = &cobra.Command{
 	Short: "Create a new group",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := navbindings.ValidateGroupName(args[0]); err != nil {
+			return err
+		}
 		mgr, err := tmux.NewManager(configDir)
 		if err != nil {
 			return err
@@ -123,15 +133,181 @@ var groupDeactivateCmd = &cobra.Command{
 	},
 }
 
+var groupShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a group's settings",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return err
+		}
+		info, ok := mgr.GroupInfo(args[0])
+		if !ok {
+			return fmt.Errorf("group %s not found", args[0])
+		}
+		if groupJSON {
+			return printGroupJSON(info)
+		}
+		prefix := info.Prefix
+		if prefix == "" {
+			prefix = "(none, no hotkeys)"
+		}
+		active := "yes"
+		if !info.Active {
+			active = "no"
+		}
+		persist := info.Persist
+		if info.PersistFile != "" {
+			persist += " (" + info.PersistFile + ")"
+		}
+		fmt.Printf("Group:    %s\n", info.Name)
+		fmt.Printf("Prefix:   %s\n", prefix)
+		fmt.Printf("Icon:     %s\n", info.Icon)
+		fmt.Printf("Active:   %s\n", active)
+		fmt.Printf("Order:    %d\n", info.Order)
+		fmt.Printf("Sessions: %d\n", info.Sessions)
+		fmt.Printf("Persist:  %s\n", persist)
+		return nil
+	},
+}
+
+var groupRenameCmd = &cobra.Command{
+	Use:   "rename [name] [new-name]",
+	Short: "Rename a group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := navbindings.ValidateGroupName(args[1]); err != nil {
+			return err
+		}
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return err
+		}
+		if err := mgr.RenameGroup(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := regenerateGroupBindings(mgr); err != nil {
+			return err
+		}
+		fmt.Printf("%s Renamed group '%s' to '%s'\n", core_theme.IconSuccess, args[0], args[1])
+		return nil
+	},
+}
+
+var groupSetPrefixCmd = &cobra.Command{
+	Use:   "set-prefix [name] [prefix]",
+	Short: "Set a group's prefix key",
+	Long: `Set the prefix key of a group, e.g. '<prefix> w' or '<grove> w'. An
+empty prefix ('') leaves the group without hotkeys.
+
+The change is rejected when the new prefix conflicts with keys bound in
+other groups; conflicts that already existed are left alone.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return err
+		}
+		if err := mgr.ValidateGroupPrefix(args[0], args[1]).Err(); err != nil {
+			return err
+		}
+		if err := mgr.SetGroupPrefix(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := regenerateGroupBindings(mgr); err != nil {
+			return err
+		}
+		fmt.Printf("%s Set prefix of group '%s' to '%s'\n", core_theme.IconSuccess, args[0], args[1])
+		return nil
+	},
+}
+
+var groupSetIconCmd = &cobra.Command{
+	Use:   "set-icon [name] [icon]",
+	Short: "Set a group's icon",
+	Long: `Set the icon shown next to a group, either a name such as 'tree',
+'repo', 'folder-star' or 'code', or a glyph. An empty icon ('') restores
+the default.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return err
+		}
+		if err := mgr.SetGroupIcon(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := regenerateGroupBindings(mgr); err != nil {
+			return err
+		}
+		fmt.Printf("%s Set icon of group '%s'\n", core_theme.IconSuccess, args[0])
+		return nil
+	},
+}
+
+var groupReorderCmd = &cobra.Command{
+	Use:   "reorder [name] [position]",
+	Short: "Move a group to a position in the group order",
+	Long: `Move a group to a position in the group order, counting from 1. The
+default group always comes first and is not counted.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		position, err := strconv.Atoi(args[1])
+		if err != nil || position < 1 {
+			return fmt.Errorf("invalid position %q: expected a number from 1", args[1])
+		}
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return err
+		}
+		if err := mgr.MoveGroup(args[0], position); err != nil {
+			return err
+		}
+		if err := regenerateGroupBindings(mgr); err != nil {
+			return err
+		}
+		fmt.Printf("%s Moved group '%s' to position %d\n", core_theme.IconSuccess, args[0], position)
+		return nil
+	},
+}
+
+// printGroupJSON prints group summaries as indented JSON.
+func printGroupJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal groups to JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// regenerateGroupBindings regenerates the bindings after a group change and
+// reloads tmux so the change takes effect.
+func regenerateGroupBindings(mgr *tmux.Manager) error {
+	if err := mgr.RegenerateBindings(); err != nil {
+		return fmt.Errorf("failed to regenerate bindings: %w", err)
+	}
+	_ = reloadTmuxConfig()
+	return nil
+}
+
 func init() {
 	groupCreateCmd.Flags().StringVarP(&groupPrefix, "prefix", "p", "", "Prefix key (e.g. '<grove> g' → C-g g key)")
 	groupDeleteCmd.Flags().BoolVar(&forceDelete, "force", false, "Force delete without confirmation")
+	groupListCmd.Flags().BoolVar(&groupJSON, "json", false, "Output as JSON")
+	groupShowCmd.Flags().BoolVar(&groupJSON, "json", false, "Output as JSON")
 
 	groupCmd.AddCommand(groupListCmd)
 	groupCmd.AddCommand(groupCreateCmd)
 	groupCmd.AddCommand(groupDeleteCmd)
 	groupCmd.AddCommand(groupActivateCmd)
 	groupCmd.AddCommand(groupDeactivateCmd)
+	groupCmd.AddCommand(groupShowCmd)
+	groupCmd.AddCommand(groupRenameCmd)
+	groupCmd.AddCommand(groupSetPrefixCmd)
+	groupCmd.AddCommand(groupSetIconCmd)
+	groupCmd.AddCommand(groupReorderCmd)
 
 	keyCmd.AddCommand(groupCmd)
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/grovetools/core/pkg/workspace"
@@ -85,64 +86,100 @@ var historyLastCmd = &cobra.Command{
 	Short:   "Switch to the most recently accessed project session",
 	Long:    `Switches to the most recently used project session without showing the interactive UI.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		mgr, err := tmux.NewManager(configDir)
-		if err != nil {
-			return fmt.Errorf("failed to initialize manager: %w", err)
-		}
+		return gotoHistorySlot(1)
+	},
+}
 
-		allProjects, err := mgr.GetAvailableProjects()
-		if err != nil {
-			return fmt.Errorf("failed to get available projects: %w", err)
-		}
-		projectSet := make(map[string]struct{})
-		for _, p := range allProjects {
-			projectSet[p.Path] = struct{}{}
+// historyGotoCmd jumps to the Nth most recently accessed project. The
+// history-slot hotkeys run it, so the slot is resolved when the key is
+// pressed rather than when bindings are generated.
+var historyGotoCmd = &cobra.Command{
+	Use:   "goto <n>",
+	Short: "Switch to the Nth most recently accessed project session",
+	Long: `Switches to the Nth most recently used project session, skipping the
+current one as 'nav history last' does: 'nav history goto 1' is the same
+as 'nav history last'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		slot, err := strconv.Atoi(args[0])
+		if err != nil || slot < 1 {
+			return fmt.Errorf("invalid history slot %q: must be a positive number", args[0])
 		}
+		return gotoHistorySlot(slot)
+	},
+}
 
-		history, err := mgr.GetAccessHistory()
-		if err != nil {
-			return fmt.Errorf("failed to load access history: %w", err)
-		}
+// gotoHistorySlot switches to the project in history slot n (1-based).
+func gotoHistorySlot(slot int) error {
+	mgr, err := tmux.NewManager(configDir)
+	if err != nil {
+		return fmt.Errorf("failed to initialize manager: %w", err)
+	}
+	paths, err := recentProjectPaths(mgr)
+	if err != nil {
+		return err
+	}
+	if slot > len(paths) {
+		return fmt.Errorf("history slot %d is empty: only %d recent session(s)", slot, len(paths))
+	}
+	path := paths[slot-1]
+	_ = mgr.RecordProjectAccess(path)
+	return mgr.Sessionize(path)
+}
 
-		var historyAccesses []*workspace.ProjectAccess
-		for _, access := range history.Projects {
-			historyAccesses = append(historyAccesses, access)
-		}
-		sort.Slice(historyAccesses, func(i, j int) bool {
-			return historyAccesses[i].LastAccessed.After(historyAccesses[j].LastAccessed)
-		})
+// recentProjectPaths returns the known projects in access history, most
+// recent first, leaving out the project of the current directory.
+func recentProjectPaths(mgr *tmux.Manager) ([]string, error) {
+	allProjects, err := mgr.GetAvailableProjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available projects: %w", err)
+	}
+	projectSet := make(map[string]struct{})
+	for _, p := range allProjects {
+		projectSet[p.Path] = struct{}{}
+	}
 
-		if len(historyAccesses) == 0 {
-			return fmt.Errorf("no session history found")
-		}
+	history, err := mgr.GetAccessHistory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load access history: %w", err)
+	}
 
-		cwd, _ := os.Getwd()
-		if cwd != "" {
-			cwd = filepath.Clean(cwd)
-		}
+	var historyAccesses []*workspace.ProjectAccess
+	for _, access := range history.Projects {
+		historyAccesses = append(historyAccesses, access)
+	}
+	sort.Slice(historyAccesses, func(i, j int) bool {
+		return historyAccesses[i].LastAccessed.After(historyAccesses[j].LastAccessed)
+	})
 
-		var latestProjectPath string
-		for _, access := range historyAccesses {
-			cleanPath := filepath.Clean(access.Path)
-			if cwd != "" && strings.EqualFold(cleanPath, cwd) {
-				continue
-			}
-			if _, ok := projectSet[access.Path]; ok {
-				latestProjectPath = access.Path
-				break
-			}
-		}
+	if len(historyAccesses) == 0 {
+		return nil, fmt.Errorf("no session history found")
+	}
+
+	cwd, _ := os.Getwd()
+	if cwd != "" {
+		cwd = filepath.Clean(cwd)
+	}
 
-		if latestProjectPath == "" {
-			return fmt.Errorf("no valid recent sessions found")
+	var paths []string
+	for _, access := range historyAccesses {
+		cleanPath := filepath.Clean(access.Path)
+		if cwd != "" && strings.EqualFold(cleanPath, cwd) {
+			continue
+		}
+		if _, ok := projectSet[access.Path]; ok {
+			paths = append(paths, access.Path)
 		}
+	}
 
-		_ = mgr.RecordProjectAccess(latestProjectPath)
-		return mgr.Sessionize(latestProjectPath)
-	},
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no valid recent sessions found")
+	}
+	return paths, nil
 }
 
 func init() {
 	historyCmd.AddCommand(historyLastCmd)
+	historyCmd.AddCommand(historyGotoCmd)
 	rootCmd.AddCommand(historyCmd)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	grovelogging "github.com/grovetools/core/logging"
@@ -17,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/nav/internal/manager"
+	navbindings "github.com/grovetools/nav/pkg/bindings"
 	"github.com/grovetools/nav/pkg/tmux"
 )
 
@@ -30,6 +32,13 @@ var (
 	listAllGroups bool
 )
 
+// Flags for key regenerate
+var (
+	regenerateTargets []string
+	regenerateDryRun  bool
+	regenerateDiff    bool
+)
+
 var keyCmd = &cobra.Command{
 	Use:   "key",
 	Short: "Manage tmux session key bindings",
@@ -38,10 +47,18 @@ var keyCmd = &cobra.Command{
 
 // displaySessionsTable shows sessions in a styled table and returns true if any sessions have paths
 func displaySessionsTable(sessions []models.TmuxSession) bool {
+	return displaySessionsTableWithDetails(sessions, nil, nil)
+}
+
+// displaySessionsTableWithDetails is displaySessionsTable with extra Action
+// and Env columns, each shown only when its map has entries (see
+// sessionActionSummary and sessionEnvSummary).
+func displaySessionsTableWithDetails(sessions []models.TmuxSession, actionByKey, envByKey map[string]string) bool {
 	// Define styles
 	keyStyle := core_theme.DefaultTheme.Highlight
 	repoStyle := core_theme.DefaultTheme.Info
 	pathStyle := core_theme.DefaultTheme.Success
+	envStyle := core_theme.DefaultTheme.Muted
 
 	// Build rows
 	var rows [][]string
@@ -51,7 +68,7 @@ func displaySessionsTable(sessions []models.TmuxSession) bool {
 		path := s.Path
 
 		// Style the key
-		styledKey := keyStyle.Render(s.Key)
+		styledKey := keyStyle.Render(navbindings.FormatKey(s.Key))
 
 		// Use configured repository name
 		var repo string
@@ -63,15 +80,33 @@ func displaySessionsTable(sessions []models.TmuxSession) bool {
 
 		if path != "" {
 			hasConfiguredSessions = true
-			path = pathStyle.Render(path)
+			if navbindings.MissingPath(path) {
+				path = core_theme.DefaultTheme.Warning.Render(path + " (missing)")
+			} else {
+				path = pathStyle.Render(path)
+			}
 		}
 
-		rows = append(rows, []string{styledKey, repo, path})
+		row := []string{styledKey, repo, path}
+		if len(actionByKey) > 0 {
+			row = append(row, actionByKey[s.Key])
+		}
+		if len(envByKey) > 0 {
+			row = append(row, envStyle.Render(envByKey[s.Key]))
+		}
+		rows = append(rows, row)
 	}
 
 	// Create styled table
+	headers := []string{"Key", "Repository", "Path"}
+	if len(actionByKey) > 0 {
+		headers = append(headers, "Action")
+	}
+	if len(envByKey) > 0 {
+		headers = append(headers, "Env")
+	}
 	t := tablecomponent.NewStyledTable().
-		Headers("Key", "Repository", "Path").
+		Headers(headers...).
 		Rows(rows...)
 
 	ulogKey.Info("Sessions table").
@@ -82,6 +117,83 @@ func displaySessionsTable(sessions []models.TmuxSession) bool {
 	return hasConfiguredSessions
 }
 
+// sessionActionSummary describes the action of each mapped key in group
+// whose binding differs from the default (sessionize, repeatable).
+func sessionActionSummary(mgr *tmux.Manager, group string, sessions []models.TmuxSession) map[string]string {
+	summary := make(map[string]string)
+	for _, s := range sessions {
+		if s.Path == "" {
+			continue
+		}
+		action := mgr.BindingAction(group, s.Path)
+		isSessionize := action.EffectiveKind() == navbindings.ActionSessionize
+		switch {
+		case isSessionize && action.Repeats():
+			continue
+		case isSessionize:
+			summary[s.Key] = action.Describe() + ", no repeat"
+		case action.Repeats():
+			summary[s.Key] = action.Describe() + ", repeat"
+		default:
+			summary[s.Key] = action.Describe()
+		}
+	}
+	return summary
+}
+
+// warnStaleSessions prints a warning naming the mapped keys in group whose
+// path no longer exists.
+func warnStaleSessions(group string, sessions []models.TmuxSession) {
+	var stale []string
+	for _, s := range sessions {
+		if s.Path != "" && navbindings.MissingPath(s.Path) {
+			stale = append(stale, navbindings.FormatKey(s.Key))
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+	pruneCmd := "nav key prune"
+	if group != "" && group != "default" {
+		pruneCmd += " --group " + group
+	}
+	ulogKey.Warn("Stale mappings").
+		Field("group", group).
+		Field("keys", stale).
+		Pretty(fmt.Sprintf("%s %d mapping(s) point to paths that no longer exist: %s. Run '%s' to unmap them.",
+			core_theme.IconWarning, len(stale), strings.Join(stale, ", "), pruneCmd)).
+		PrettyOnly().
+		Emit()
+}
+
+// sessionEnvSummary maps each session key to the names of the environment
+// variables its session is created with. Values are left out since env
+// often carries credentials. Resolution errors (e.g. a missing env_file)
+// are shown in place of the names.
+func sessionEnvSummary(mgr *tmux.Manager, sessions []models.TmuxSession) map[string]string {
+	summary := make(map[string]string)
+	for _, s := range sessions {
+		if s.Path == "" {
+			continue
+		}
+		env, err := mgr.ResolveEnv(s.Path)
+		if err != nil {
+			summary[s.Key] = "error: " + err.Error()
+			continue
+		}
+		if len(env) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(env))
+		for k := range env {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		summary[s.Key] = strings.Join(names, ", ")
+	}
+	return summary
+}
+
 var keyListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all configured session keys",
@@ -112,7 +224,8 @@ var keyListCmd = &cobra.Command{
 
 				fmt.Printf("\n--- Group: %s (Prefix: %s) ---\n", g, prefixMode)
 				if len(sessions) > 0 {
-					displaySessionsTable(sessions)
+					displaySessionsTableWithDetails(sessions, sessionActionSummary(mgr, g, sessions), sessionEnvSummary(mgr, sessions))
+					warnStaleSessions(g, sessions)
 				} else {
 					fmt.Println("No sessions configured")
 				}
@@ -175,12 +288,19 @@ var keyListCmd = &cobra.Command{
 			keyStyle := core_theme.DefaultTheme.Highlight
 			repoStyle := core_theme.DefaultTheme.Info
 
+			actions := sessionActionSummary(mgr, mgr.GetActiveGroup(), sessions)
 			var outputLines []string
 			for _, s := range sessions {
 				// Only show mapped sessions in compact view
 				if s.Path != "" {
 					repo := filepath.Base(s.Path)
-					line := fmt.Sprintf("%s: %s", keyStyle.Render(s.Key), repoStyle.Render(repo))
+					line := fmt.Sprintf("%s: %s", keyStyle.Render(navbindings.FormatKey(s.Key)), repoStyle.Render(repo))
+					if action, ok := actions[s.Key]; ok {
+						line += core_theme.DefaultTheme.Muted.Render(" [" + action + "]")
+					}
+					if navbindings.MissingPath(s.Path) {
+						line += core_theme.DefaultTheme.Warning.Render(" (missing)")
+					}
 					outputLines = append(outputLines, line)
 				}
 			}
@@ -192,8 +312,9 @@ var keyListCmd = &cobra.Command{
 				Emit()
 		} else {
 			// Default to the existing table display
-			displaySessionsTable(sessions)
+			displaySessionsTableWithDetails(sessions, sessionActionSummary(mgr, mgr.GetActiveGroup(), sessions), sessionEnvSummary(mgr, sessions))
 		}
+		warnStaleSessions(mgr.GetActiveGroup(), sessions)
 		return nil
 	},
 }
@@ -293,7 +414,7 @@ var keyUpdateCmd = &cobra.Command{
 		}
 		ulogKey.Info("Available keys display").
 			Field("free_keys", freeKeys).
-			Pretty(fmt.Sprintf("\nAvailable keys:\n  %s\n\nEnter new key (or press Enter to cancel): ", strings.Join(freeKeys, ", "))).
+			Pretty(fmt.Sprintf("\nAvailable keys:\n  %s\n\nEnter new key or chord, e.g. 'gw' (or press Enter to cancel): ", strings.Join(freeKeys, ", "))).
 			PrettyOnly().
 			Emit()
 
@@ -317,15 +438,8 @@ var keyUpdateCmd = &cobra.Command{
 			return fmt.Errorf("key '%s' is already in use", newKey)
 		}
 
-		validKey := false
-		for _, k := range availableKeys {
-			if k == newKey {
-				validKey = true
-				break
-			}
-		}
-		if !validKey {
-			return fmt.Errorf("'%s' is not a valid key. Available keys: %s", newKey, strings.Join(availableKeys, ", "))
+		if !mgr.IsValidKey(newKey) {
+			return fmt.Errorf("'%s' is not a valid key. Available keys: %s (or a chord of them, e.g. 'gw')", newKey, strings.Join(availableKeys, ", "))
 		}
 
 		// Update the session key
@@ -880,7 +994,25 @@ func setParentForClonedProjectsInKeyAdd(projects []manager.DiscoveredProject) {
 var keyRegenerateCmd = &cobra.Command{
 	Use:   "regenerate",
 	Short: "Regenerate tmux key bindings configuration",
-	Long:  `Regenerate the tmux key bindings file based on current configuration and sessions.`,
+	Long: `Regenerate the key bindings files based on current configuration and sessions.
+
+By default the tmux and tuimux bindings are regenerated. Use --target to
+pick other outputs (comma separated, or "all"):
+
+  tmux     tmux key tables (generated-bindings.conf)
+  tuimux   tuimux keybindings (generated-bindings-tuimux.toml)
+  zsh      zle widgets bound under Alt-g (generated-bindings.zsh)
+  bash     readline bindings under Alt-g (generated-bindings.bash)
+  fish     fish bindings under Alt-g (generated-bindings.fish)
+  zellij   zellij keybinds block for tmux mode; popup, tui and nav actions
+           only, since sessionize drives tmux (generated-bindings.kdl)
+  json     machine-readable manifest (generated-bindings.json)
+
+Files are written atomically to the nav directory in the grove cache, and
+per-group tmux files left behind by deleted groups are removed.
+
+Use --dry-run to list the files that would change, or --diff to show a
+unified diff against the files on disk. Neither writes anything.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mgr, err := tmux.NewManager(configDir)
 		if err != nil {
@@ -912,20 +1044,52 @@ var keyRegenerateCmd = &cobra.Command{
 				prefixMode = fmt.Sprintf("Prefix: %s", prefix)
 			}
 		}
+		targets := regenerateTargets
+		if len(targets) == 0 {
+			targets = navbindings.DefaultTargets()
+		}
+		plan, err := mgr.PlanBindings(targets)
+		if err != nil {
+			return fmt.Errorf("failed to regenerate bindings: %w", err)
+		}
+
+		if regenerateDryRun || regenerateDiff {
+			reportBindingsPlan(plan)
+			return nil
+		}
+
 		ulogKey.Info("Regenerating bindings").
 			Field("prefix_mode", prefixMode).
 			Pretty(fmt.Sprintf("%s Regenerating bindings (Prefix: %s)...", core_theme.IconRunning, prefixMode)).
 			PrettyOnly().
 			Emit()
 
-		if err := mgr.RegenerateBindings(); err != nil {
+		if err := plan.Apply(); err != nil {
 			return fmt.Errorf("failed to regenerate bindings: %w", err)
 		}
 
+		reloadTmux := false
+		for _, t := range targets {
+			if t == "tmux" || t == "all" {
+				reloadTmux = true
+			}
+		}
+		if !reloadTmux {
+			ulogKey.Success("Bindings regenerated").
+				Field("targets", targets).
+				Field("changed_files", len(plan.Changes)).
+				Pretty(fmt.Sprintf("%s Bindings regenerated for: %s", core_theme.IconSuccess, strings.Join(targets, ", "))).
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+
 		// Auto-reload tmux on all running servers
 		coretmux.ReloadAllServers()
 
 		ulogKey.Success("Bindings regenerated").
+			Field("targets", targets).
+			Field("changed_files", len(plan.Changes)).
 			Pretty(core_theme.IconSuccess + " Bindings regenerated and tmux reloaded!").
 			PrettyOnly().
 			Emit()
@@ -933,6 +1097,44 @@ var keyRegenerateCmd = &cobra.Command{
 	},
 }
 
+// reportBindingsPlan prints the pending binding changes for --dry-run and
+// --diff.
+func reportBindingsPlan(plan *navbindings.Plan) {
+	if len(plan.Changes) == 0 {
+		ulogKey.Info("Bindings up to date").
+			Pretty(core_theme.IconSuccess + " Bindings are up to date").
+			PrettyOnly().
+			Emit()
+		return
+	}
+
+	if regenerateDiff {
+		ulogKey.Info("Bindings diff").
+			Field("changed_files", len(plan.Changes)).
+			Pretty(strings.TrimSuffix(plan.Diff(), "\n")).
+			PrettyOnly().
+			Emit()
+		return
+	}
+
+	var lines []string
+	for _, c := range plan.Changes {
+		action := "update"
+		switch {
+		case c.Remove:
+			action = "remove"
+		case c.Old == nil:
+			action = "create"
+		}
+		lines = append(lines, fmt.Sprintf("  %-6s %s (%s)", action, c.Path, c.Target))
+	}
+	ulogKey.Info("Bindings dry run").
+		Field("changed_files", len(plan.Changes)).
+		Pretty(fmt.Sprintf("%s %d file(s) would change:\n%s", core_theme.IconInfo, len(plan.Changes), strings.Join(lines, "\n"))).
+		PrettyOnly().
+		Emit()
+}
+
 func init() {
 	// Add the new --style flag to the command
 	keyListCmd.Flags().StringVar(&listStyle, "style", "table", "Output style: table or compact")
@@ -946,5 +1148,10 @@ func init() {
 	keyCmd.AddCommand(keyEditCmd)
 	keyCmd.AddCommand(keyAddCmd)
 	keyCmd.AddCommand(keyUnmapCmd)
+	keyRegenerateCmd.Flags().StringSliceVar(&regenerateTargets, "target", nil, "Binding targets to generate: "+strings.Join(navbindings.Targets(), ", ")+", or all (default tmux,tuimux)")
+
+	keyRegenerateCmd.Flags().BoolVar(&regenerateDryRun, "dry-run", false, "List the files that would change without writing them")
+	keyRegenerateCmd.Flags().BoolVar(&regenerateDiff, "diff", false, "Show a unified diff of the changes without writing them")
+
 	keyCmd.AddCommand(keyRegenerateCmd)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/grovetools/core/pkg/mux"
 	"github.com/spf13/cobra"
 
+	navbindings "github.com/grovetools/nav/pkg/bindings"
 	"github.com/grovetools/nav/pkg/tmux"
 )
 
@@ -29,7 +30,11 @@ func reloadTmuxConfig() error {
 	if mux.ActiveMux() == mux.MuxNone {
 		return fmt.Errorf("not in a tmux session")
 	}
-	cmd := tmux.Command("source-file", expandPath("~/.tmux.conf"))
+	confPath, err := navbindings.UserTmuxConfPath()
+	if err != nil {
+		return err
+	}
+	cmd := tmux.Command("source-file", confPath)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("tmux reload failed: %s", string(output))
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/workspace"
+	core_theme "github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var keyMenuAllGroups bool
+
+var keyMenuCmd = &cobra.Command{
+	Use:   "menu",
+	Short: "Show a which-key listing of the mapped keys",
+	Long: `Print each mapped key with its project and whether the project's session
+is running. Under tuimux, ? after a group's prefix opens this listing for
+the group in a popup; under tmux the same listing is a display-menu opened
+with ? or by pausing after a group's prefix.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		group := targetGroup
+		if group == "" {
+			group = mgr.GetActiveGroup()
+		}
+
+		running := runningSessionNames()
+		opts := navbindings.Options{CacheDir: paths.CacheDir(), SkipMissing: true}
+		var sections []string
+		for _, g := range mgr.GroupBindings() {
+			if !keyMenuAllGroups && g.Name != group {
+				continue
+			}
+			sections = append(sections, keyMenuSection(mgr, g, navbindings.MenuItems(g, opts), running))
+		}
+		if len(sections) == 0 {
+			return fmt.Errorf("group '%s' has no bindings", group)
+		}
+		ulogKey.Info("Key menu").
+			Field("group_count", len(sections)).
+			Pretty(strings.Join(sections, "\n\n")).
+			PrettyOnly().
+			Emit()
+		return nil
+	},
+}
+
+// keyMenuSection renders one group's keys under a heading with its icon
+// and prefix.
+func keyMenuSection(mgr *tmux.Manager, g navbindings.GroupBinding, items []navbindings.MenuItem, running map[string]bool) string {
+	var b strings.Builder
+	b.WriteString(core_theme.DefaultTheme.Info.Render(fmt.Sprintf("%s %s", g.Icon, g.Name)))
+	b.WriteString(core_theme.DefaultTheme.Muted.Render(" " + g.Prefix))
+	if len(items) == 0 {
+		b.WriteString("\n  " + core_theme.DefaultTheme.Muted.Render("no keys mapped"))
+	}
+	for _, item := range items {
+		dot := core_theme.DefaultTheme.Muted.Render("○")
+		if node, err := workspace.GetProjectByPath(item.Path); err == nil && running[mgr.SessionName(node)] {
+			dot = core_theme.DefaultTheme.Success.Render("●")
+		}
+		key := core_theme.DefaultTheme.Highlight.Render(fmt.Sprintf("%-5s", navbindings.FormatKey(item.Key)))
+		line := fmt.Sprintf("\n  %s %s %s", key, dot, item.Name)
+		if item.Action.EffectiveKind() != navbindings.ActionSessionize {
+			line += core_theme.DefaultTheme.Muted.Render(" (" + item.Action.Describe() + ")")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// runningSessionNames returns the sessions of the active multiplexer, or
+// none when it cannot be reached.
+func runningSessionNames() map[string]bool {
+	ctx := context.Background()
+	names := make(map[string]bool)
+	engine, err := mux.DetectMuxEngine(ctx)
+	if err != nil {
+		return names
+	}
+	sessions, err := engine.ListSessions(ctx)
+	if err != nil {
+		return names
+	}
+	for _, s := range sessions {
+		names[s.Name] = true
+	}
+	return names
+}
+
+func init() {
+	keyMenuCmd.Flags().BoolVarP(&keyMenuAllGroups, "all-groups", "a", false, "Show every workspace group")
+
+	keyCmd.AddCommand(keyMenuCmd)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tablecomponent "github.com/grovetools/core/tui/components/table"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var (
+	keyPruneDryRun    bool
+	keyPruneAllGroups bool
+	keyPruneUndo      bool
+	keyPruneJSON      bool
+)
+
+// keyPruneResult is one mapping reported by `nav key prune --json`.
+type keyPruneResult struct {
+	manager.StaleMapping
+	Unmapped bool `json:"unmapped"`
+}
+
+var keyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Unmap keys whose path no longer exists",
+	Long: `Unmap keys mapped to paths that no longer exist, such as deleted
+worktrees or moved repositories, and regenerate the bindings.
+
+Locked keys are never unmapped. The removed mappings are recorded so the
+last prune can be reverted with --undo.
+
+  nav key prune --dry-run
+  nav key prune --all-groups
+  nav key prune --undo`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		if targetGroup != "" {
+			mgr.SetActiveGroup(targetGroup)
+		}
+
+		if keyPruneUndo {
+			return undoKeyPrune(mgr)
+		}
+
+		stale := mgr.FindStaleMappings(keyPruneAllGroups)
+		unmapped := make(map[string]bool)
+		if !keyPruneDryRun {
+			pruned, err := mgr.PruneStaleMappings(stale)
+			if err != nil {
+				return fmt.Errorf("failed to prune mappings: %w", err)
+			}
+			for _, s := range pruned {
+				unmapped[s.Group+" "+s.Key] = true
+			}
+			if len(pruned) > 0 {
+				if err := mgr.RegenerateBindings(); err != nil {
+					return fmt.Errorf("failed to regenerate bindings: %w", err)
+				}
+				_ = reloadTmuxConfig()
+			}
+		}
+
+		results := make([]keyPruneResult, 0, len(stale))
+		for _, s := range stale {
+			results = append(results, keyPruneResult{StaleMapping: s, Unmapped: unmapped[s.Group+" "+s.Key]})
+		}
+		return reportKeyPrune(results)
+	},
+}
+
+// reportKeyPrune prints the stale mappings and what happened to each.
+func reportKeyPrune(results []keyPruneResult) error {
+	count := 0
+	for _, r := range results {
+		if r.Unmapped {
+			count++
+		}
+	}
+
+	if keyPruneJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal prune results to JSON: %w", err)
+		}
+		ulogKey.Info("Key prune").
+			Field("format", "json").
+			Field("unmapped_count", count).
+			Pretty(string(data)).
+			PrettyOnly().
+			Emit()
+		return nil
+	}
+
+	if len(results) == 0 {
+		ulogKey.Info("No stale mappings").
+			Pretty(theme.IconInfo + " No mappings point to missing paths").
+			PrettyOnly().
+			Emit()
+		return nil
+	}
+
+	var rows [][]string
+	for _, r := range results {
+		var status string
+		switch {
+		case r.Locked:
+			status = theme.DefaultTheme.Muted.Render("kept: locked")
+		case r.Unmapped:
+			status = theme.DefaultTheme.Success.Render("unmapped")
+		default:
+			status = "would unmap"
+		}
+		rows = append(rows, []string{
+			r.Group,
+			theme.DefaultTheme.Highlight.Render(navbindings.FormatKey(r.Key)),
+			r.Path,
+			status,
+		})
+	}
+	t := tablecomponent.NewStyledTable().
+		Headers("Group", "Key", "Missing Path", "Status").
+		Rows(rows...)
+
+	summary := fmt.Sprintf("%s Unmapped %d stale key(s); run 'nav key prune --undo' to restore them", theme.IconSuccess, count)
+	if keyPruneDryRun {
+		summary = fmt.Sprintf("%s Dry run: no keys were unmapped", theme.IconInfo)
+	} else if count == 0 {
+		summary = fmt.Sprintf("%s Nothing unmapped", theme.IconInfo)
+	}
+	ulogKey.Info("Key prune").
+		Field("dry_run", keyPruneDryRun).
+		Field("unmapped_count", count).
+		Pretty(t.String() + "\n" + summary).
+		PrettyOnly().
+		Emit()
+	return nil
+}
+
+// undoKeyPrune restores the mappings removed by the last prune.
+func undoKeyPrune(mgr *tmux.Manager) error {
+	restored, skipped, err := mgr.UndoPrune()
+	if err != nil {
+		return err
+	}
+	if len(restored) > 0 {
+		if err := mgr.RegenerateBindings(); err != nil {
+			return fmt.Errorf("failed to regenerate bindings: %w", err)
+		}
+		_ = reloadTmuxConfig()
+	}
+
+	pretty := fmt.Sprintf("%s Restored %d mapping(s)", theme.IconSuccess, len(restored))
+	for _, s := range skipped {
+		pretty += fmt.Sprintf("\n%s %s %s: key was mapped again, not restoring %s",
+			theme.IconWarning, s.Group, navbindings.FormatKey(s.Key), s.Path)
+	}
+	ulogKey.Success("Prune undone").
+		Field("restored_count", len(restored)).
+		Field("skipped_count", len(skipped)).
+		Pretty(pretty).
+		PrettyOnly().
+		Emit()
+	return nil
+}
+
+func init() {
+	keyPruneCmd.Flags().BoolVar(&keyPruneDryRun, "dry-run", false, "Show stale mappings without unmapping them")
+	keyPruneCmd.Flags().BoolVar(&keyPruneAllGroups, "all-groups", false, "Prune every workspace group, not just the active one")
+	keyPruneCmd.Flags().BoolVar(&keyPruneUndo, "undo", false, "Restore the mappings removed by the last prune")
+	keyPruneCmd.Flags().BoolVar(&keyPruneJSON, "json", false, "Output the result as JSON")
+	keyPruneCmd.MarkFlagsMutuallyExclusive("undo", "dry-run")
+
+	keyCmd.AddCommand(keyPruneCmd)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tablecomponent "github.com/grovetools/core/tui/components/table"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var keyValidateJSON bool
+
+var keyValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Report every problem with the key mappings",
+	Long: `Check the mappings of every active group and report all problems at
+once rather than stopping at the first:
+
+  empty-key                     a mapping has no key (error)
+  relative-path                 a path is neither absolute nor ~-prefixed (error)
+  prefix-conflict               a key starts with another group's prefix (error)
+  ambiguous-chord               a key is also the start of a chord (error)
+  cross-group-collision         groups sharing a prefix bind the same key (error)
+  unquotable-path               a path has control characters or invalid UTF-8 (error)
+  duplicate-path-across-groups  a path is mapped in more than one group (warning)
+  missing-path                  a mapped path does not exist (warning)
+
+Exits non-zero when there are errors.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		report := mgr.ValidateBindings()
+		errCount := len(report.Errors())
+
+		if keyValidateJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal validation report to JSON: %w", err)
+			}
+			ulogKey.Info("Key validate").
+				Field("format", "json").
+				Field("error_count", errCount).
+				Pretty(string(data)).
+				PrettyOnly().
+				Emit()
+		} else {
+			reportKeyValidate(report, errCount)
+		}
+
+		if errCount > 0 {
+			return fmt.Errorf("%d validation error(s)", errCount)
+		}
+		return nil
+	},
+}
+
+// reportKeyValidate prints the issues as a table followed by a summary line.
+func reportKeyValidate(report *navbindings.Report, errCount int) {
+	if len(report.Issues) == 0 {
+		ulogKey.Success("Key mappings valid").
+			Pretty(theme.IconSuccess + " No problems found").
+			PrettyOnly().
+			Emit()
+		return
+	}
+
+	var rows [][]string
+	for _, issue := range report.Issues {
+		severity := theme.DefaultTheme.Warning.Render(theme.IconWarning + " warning")
+		if issue.Severity == navbindings.SeverityError {
+			severity = theme.DefaultTheme.Error.Render(theme.IconError + " error")
+		}
+		key := ""
+		if issue.Key != "" {
+			key = theme.DefaultTheme.Highlight.Render(navbindings.FormatKey(issue.Key))
+		}
+		rows = append(rows, []string{severity, issue.Code, issue.Group, key, issue.Message})
+	}
+	t := tablecomponent.NewStyledTable().
+		Headers("Severity", "Code", "Group", "Key", "Message").
+		Rows(rows...)
+
+	warnCount := len(report.Issues) - errCount
+	summary := fmt.Sprintf("%s %d warning(s), no errors", theme.IconWarning, warnCount)
+	if errCount > 0 {
+		summary = fmt.Sprintf("%s %d error(s), %d warning(s)", theme.IconError, errCount, warnCount)
+	}
+	ulogKey.Info("Key validate").
+		Field("issue_count", len(report.Issues)).
+		Field("error_count", errCount).
+		Pretty(t.String() + "\n" + summary).
+		PrettyOnly().
+		Emit()
+}
+
+func init() {
+	keyValidateCmd.Flags().BoolVar(&keyValidateJSON, "json", false, "Output the report as JSON")
+
+	keyCmd.AddCommand(keyValidateCmd)
+}
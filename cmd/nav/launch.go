@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	grovelogging "github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/mux"
 	"github.com/grovetools/core/tui/theme"
 	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/pkg/tmux"
 )
 
 var ulogLaunch = grovelogging.NewUnifiedLogger("nav.launch")
@@ -17,12 +21,14 @@ var (
 	launchWindowName string
 	launchWorkingDir string
 	launchPanes      []string
+	launchFile       string
 )
 
 var launchCmd = &cobra.Command{
 	Use:   "launch <session-name>",
 	Short: "Launch a new tmux session with optional panes",
-	Long: `Launch a new tmux session with support for multiple panes.
+	Long: `Launch a new tmux session with support for multiple panes, or build
+one from a spec file with several windows.
 
 Examples:
   # Simple session
@@ -35,12 +41,54 @@ Examples:
   nav launch dev-session --pane "vim main.go" --pane "go test -v" --pane "htop"
 
   # Complex panes with working directories (format: command[@workdir])
-  nav launch dev-session --pane "npm run dev@/app/frontend" --pane "go run .@/app/backend"`,
+  nav launch dev-session --pane "npm run dev@/app/frontend" --pane "go run .@/app/backend"
+
+  # Session built from a spec file (use - to read the spec from stdin)
+  nav launch dev-session --file dev.yml
+  cat dev.yml | nav launch dev-session --file -
+
+The text after the last '@' in a --pane value is only treated as a working
+directory when it looks like a path (starts with /, ~, ./ or ../), so
+commands such as "npx create-app@latest" are passed through untouched.
+Write a relative working directory with a leading ./ ("make@./web", not
+"make@web").
+
+A spec file has an optional root 'dir', session-wide 'env' and a list of windows. Each pane may
+set a command, dir, split (horizontal or vertical), size (percent), env and
+focus:
+
+  dir: ~/code/app
+  env:
+    GOFLAGS: -race
+  windows:
+    - name: dev
+      panes:
+        - command: nvim .
+          focus: true
+        - command: npm run dev
+          split: horizontal
+          size: 30
+          env:
+            PORT: "3000"
+    - name: logs
+      dir: ./logs
+      panes:
+        - command: tail -f app.log
+
+The spec is validated before anything is created, so a bad spec never
+leaves a half-built session behind.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		sessionName := args[0]
 		ctx := context.Background()
 
+		if launchFile != "" {
+			if len(launchPanes) > 0 || launchWindowName != "" {
+				return fmt.Errorf("--file cannot be combined with --pane or --window-name")
+			}
+			return launchFromSpec(ctx, sessionName, launchFile)
+		}
+
 		engine, err := mux.DetectMuxEngine(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to detect mux engine: %w", err)
@@ -49,17 +97,7 @@ Examples:
 		// Parse pane configurations
 		var paneOpts []mux.PaneOptions
 		for _, paneStr := range launchPanes {
-			pane := mux.PaneOptions{}
-
-			// Check for @workdir syntax
-			if idx := strings.LastIndex(paneStr, "@"); idx != -1 {
-				pane.Command = paneStr[:idx]
-				pane.WorkingDirectory = paneStr[idx+1:]
-			} else {
-				pane.Command = paneStr
-			}
-
-			paneOpts = append(paneOpts, pane)
+			paneOpts = append(paneOpts, parsePaneFlag(paneStr))
 		}
 
 		opts := mux.LaunchOptions{
@@ -88,8 +126,104 @@ Examples:
 	},
 }
 
+// launchFromSpec builds a session from a spec file, or stdin when path is
+// "-". The spec is fully validated, and the session name checked, before
+// any tmux command runs.
+func launchFromSpec(ctx context.Context, sessionName, path string) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read launch spec: %w", err)
+	}
+
+	spec, err := tmux.ParseLaunchSpec(data)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	root := spec.ResolveRoot(cwd, launchWorkingDir)
+	if err := spec.Validate(root); err != nil {
+		return fmt.Errorf("invalid launch spec: %w", err)
+	}
+
+	// Specs build splits and extra windows with the tmux CLI. The engine is
+	// optional: without a running server, new-session starts one.
+	var engine mux.MuxEngine
+	switch mux.ActiveMux() {
+	case mux.MuxTmux:
+		engine, err = mux.DetectMuxEngine(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to detect mux engine: %w", err)
+		}
+		exists, err := engine.SessionExists(ctx, sessionName)
+		if err != nil {
+			return fmt.Errorf("failed to check session: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("session '%s' already exists", sessionName)
+		}
+	case mux.MuxNone:
+		if err := tmux.Command("has-session", "-t", "="+sessionName).Run(); err == nil {
+			return fmt.Errorf("session '%s' already exists", sessionName)
+		}
+	default:
+		return fmt.Errorf("--file is only supported with tmux")
+	}
+
+	if err := tmux.LaunchLayout(ctx, engine, sessionName, root, spec.Layout(), spec.Env); err != nil {
+		return fmt.Errorf("failed to launch session: %w", err)
+	}
+
+	ulogLaunch.Success("Session launched").
+		Field("session", sessionName).
+		Field("spec", path).
+		Field("working_dir", root).
+		Field("window_count", len(spec.Windows)).
+		Pretty(fmt.Sprintf("%s Session '%s' launched successfully\n\nTo attach to this session, run:\n  tmux attach-session -t %s",
+			theme.IconSuccess, sessionName, sessionName)).
+		PrettyOnly().
+		Emit()
+
+	return nil
+}
+
+// parsePaneFlag parses a --pane value of the form command[@workdir]. Only
+// a suffix after the last '@' that looks like a path is split off, so '@'
+// inside commands (npm versions, email addresses, git refs) is preserved.
+func parsePaneFlag(value string) mux.PaneOptions {
+	idx := strings.LastIndex(value, "@")
+	if idx == -1 {
+		return mux.PaneOptions{Command: value}
+	}
+	dir := value[idx+1:]
+	if !looksLikePath(dir) {
+		return mux.PaneOptions{Command: value}
+	}
+	return mux.PaneOptions{Command: value[:idx], WorkingDirectory: dir}
+}
+
+// looksLikePath reports whether s is an absolute, home-relative or
+// explicitly relative path.
+func looksLikePath(s string) bool {
+	return s == "~" || s == "." || s == ".." ||
+		strings.HasPrefix(s, "/") ||
+		strings.HasPrefix(s, "~/") ||
+		strings.HasPrefix(s, "./") ||
+		strings.HasPrefix(s, "../")
+}
+
 func init() {
 	launchCmd.Flags().StringVar(&launchWindowName, "window-name", "", "Name for the initial window")
 	launchCmd.Flags().StringVar(&launchWorkingDir, "working-dir", "", "Working directory for the session")
-	launchCmd.Flags().StringArrayVar(&launchPanes, "pane", []string{}, "Add a pane with command (can be used multiple times). Format: 'command[@workdir]'")
+	launchCmd.Flags().StringArrayVar(&launchPanes, "pane", []string{}, "Add a pane with command (can be used multiple times). Format: 'command[@workdir]', where workdir starts with /, ~, ./ or ../")
+	launchCmd.Flags().StringVarP(&launchFile, "file", "f", "", "Build the session from a YAML spec file ('-' reads from stdin)")
 }
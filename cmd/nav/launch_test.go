@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestParsePaneFlag(t *testing.T) {
+	tests := []struct {
+		value   string
+		command string
+		dir     string
+	}{
+		{value: "htop", command: "htop"},
+		{value: "ssh user@host", command: "ssh user@host"},
+		{value: "npx create-app@latest", command: "npx create-app@latest"},
+		{value: "npm run dev@/app/frontend", command: "npm run dev", dir: "/app/frontend"},
+		{value: "vim@~/notes", command: "vim", dir: "~/notes"},
+		{value: "vim@~", command: "vim", dir: "~"},
+		{value: "make@./web", command: "make", dir: "./web"},
+		{value: "make@../web", command: "make", dir: "../web"},
+		{value: "make@web", command: "make@web"},
+		{value: "git log a@b@/repo", command: "git log a@b", dir: "/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := parsePaneFlag(tt.value)
+			if got.Command != tt.command || got.WorkingDirectory != tt.dir {
+				t.Errorf("parsePaneFlag(%q) = {%q, %q}, want {%q, %q}",
+					tt.value, got.Command, got.WorkingDirectory, tt.command, tt.dir)
+			}
+		})
+	}
+}
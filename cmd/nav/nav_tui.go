@@ -30,6 +30,9 @@ type NavTUIOptions struct {
 	// first opened — normally the current working directory's
 	// ecosystem root.
 	CwdFocusPath string
+
+	// Filter pre-fills the sessionizer tab's filter input.
+	Filter string
 }
 
 // runNavTUI runs the unified nav TUI starting in the keymanage tab.
@@ -94,7 +97,7 @@ func runNavTUIWithTab(initialTab navapp.Tab, opts NavTUIOptions) error {
 
 	cfg := navapp.Config{
 		InitialTab:    initialTab,
-		NewSessionize: newSessionizeFactory(mgr, client, tuimuxEngine, opts.CwdFocusPath),
+		NewSessionize: newSessionizeFactory(mgr, client, tuimuxEngine, opts.CwdFocusPath, opts.Filter),
 		NewKeymanage:  newKeymanageFactory(mgr, client, tuimuxEngine, cwd),
 		NewHistory:    newHistoryFactory(mgr),
 		NewGroups:     newGroupsFactory(mgr),
@@ -125,6 +128,9 @@ func runNavTUIWithTab(initialTab navapp.Tab, opts NavTUIOptions) error {
 
 	model = navapp.New(cfg)
 
+	// Hooks the TUI started in the background finish before nav exits.
+	defer waitForTUIHooks()
+
 	compModel := compositor.NewModel(model)
 	p := tea.NewProgram(compModel, tea.WithAltScreen())
 	finalModel, runErr := p.Run()
@@ -198,7 +204,7 @@ func handlePostExit(finalModel tea.Model, mgr *tmux.Manager, client *tmuxclient.
 			if selected.IsWorktree() && selected.ParentProjectPath != "" {
 				_ = mgr.RecordProjectAccess(selected.ParentProjectPath)
 			}
-			return sessionizeProject(selected)
+			return sessionizeProject(mgr, selected)
 		}
 	}
 
@@ -210,7 +216,7 @@ func handlePostExit(finalModel tea.Model, mgr *tmux.Manager, client *tmuxclient.
 			if err != nil {
 				return fmt.Errorf("failed to get project info for path %s: %w", selected.Path, err)
 			}
-			return sessionizeProject(&manager.SessionizeProject{WorkspaceNode: node})
+			return sessionizeProject(mgr, &manager.SessionizeProject{WorkspaceNode: node})
 		}
 	}
 
@@ -234,7 +240,7 @@ func handlePostExit(finalModel tea.Model, mgr *tmux.Manager, client *tmuxclient.
 // factory reads the project cache (or falls back to the full project
 // loader) on first access so cold start and warm start produce the
 // same sorted, ecosystem-grouped project list.
-func newSessionizeFactory(mgr *tmux.Manager, client *tmuxclient.Client, tuimuxEngine mux.MuxTUIEngine, cwdFocusPath string) navapp.SessionizeFactory {
+func newSessionizeFactory(mgr *tmux.Manager, client *tmuxclient.Client, tuimuxEngine mux.MuxTUIEngine, cwdFocusPath, filter string) navapp.SessionizeFactory {
 	return func() *sessionizer.Model {
 		usedCache := false
 		var projects []manager.SessionizeProject
@@ -292,19 +298,21 @@ func newSessionizeFactory(mgr *tmux.Manager, client *tmuxclient.Client, tuimuxEn
 			SearchPaths:         searchPaths,
 			Features:            mgr.GetResolvedFeatures(),
 			CwdFocusPath:        cwdFocusPath,
+			InitialFilter:       filter,
 			ActiveWorkspacePath: cwd,
 			UsedCache:           usedCache,
 			CurrentSession:      currentSession,
 			LoadProjects:        buildProjectLoader(mgr, configDir),
 			ReloadConfig:        reloadTmuxConfig,
 			KeyMap:              sessionizeKeys,
+			SessionNameTemplate: mgr.GetSessionNameTemplate(),
 		}
 		if tuimuxEngine != nil {
 			driver := NewTuimuxDriver(tuimuxEngine)
 			cfg.SessionDriver = driver
 			cfg.SessionStateProvider = driver
 		} else if client != nil {
-			driver := NewTmuxDriver(client)
+			driver := NewTmuxDriver(client, mgr)
 			cfg.SessionDriver = driver
 			cfg.SessionStateProvider = driver
 		}
@@ -335,19 +343,21 @@ func newKeymanageFactory(mgr *tmux.Manager, client *tmuxclient.Client, tuimuxEng
 		if tuimuxEngine != nil {
 			driver = NewTuimuxDriver(tuimuxEngine)
 		} else if client != nil {
-			driver = NewTmuxDriver(client)
+			driver = NewTmuxDriver(client, mgr)
 		}
 
 		return keymanage.New(keymanage.Config{
-			Store:            mgr,
-			SessionDriver:    driver,
-			ConfigDir:        configDir,
-			CwdPath:          cwd,
-			Features:         mgr.GetResolvedFeatures(),
-			EnrichedProjects: enrichedProjects,
-			UsedCache:        usedCache,
-			ReloadConfig:     reloadTmuxConfig,
-			KeyMap:           manageKeys,
+			Store:               mgr,
+			SessionDriver:       driver,
+			ConfigDir:           configDir,
+			CwdPath:             cwd,
+			Features:            mgr.GetResolvedFeatures(),
+			EnrichedProjects:    enrichedProjects,
+			UsedCache:           usedCache,
+			ReloadConfig:        reloadTmuxConfig,
+			KeyMap:              manageKeys,
+			SessionNameTemplate: mgr.GetSessionNameTemplate(),
+			KnownProjects:       mgr.KnownProjectNodes,
 		})
 	}
 }
@@ -2,17 +2,29 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/grovetools/core/pkg/mux"
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/nav/internal/manager"
 	"github.com/grovetools/nav/pkg/tmux"
 )
 
+var (
+	recordSessionEvent string
+	recordSessionName  string
+)
+
 var recordSessionCmd = &cobra.Command{
 	Use:   "record-session",
 	Short: "Record the current tmux session to access history",
-	Long:  `Records the current tmux session's working directory to the access history. Designed to be called from a tmux hook (client-session-changed) to track session switches.`,
+	Long: `Records the current tmux session's working directory to the access history. Designed to be called from a tmux hook (client-session-changed) to track session switches.
+
+With --event closed or --event detached, records instead that the session
+named by --session ended, for the session-closed and client-detached hooks.
+A closed session's path is the one it had when nav last saw a switch into it.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Must be in tmux
 		if mux.ActiveMux() == mux.MuxNone {
@@ -26,6 +38,27 @@ var recordSessionCmd = &cobra.Command{
 			return nil
 		}
 
+		switch recordSessionEvent {
+		case "":
+		case manager.SessionEventClosed, manager.SessionEventDetached:
+			if recordSessionName == "" {
+				return nil
+			}
+			mgr, err := tmux.NewManager(configDir)
+			if err != nil {
+				return nil
+			}
+			// A closed session is gone; its path comes from the session log.
+			var sessionPath string
+			if recordSessionEvent == manager.SessionEventDetached {
+				sessionPath, _ = engine.GetSessionPath(ctx, recordSessionName)
+			}
+			_ = mgr.RecordSessionEnd(recordSessionName, sessionPath, recordSessionEvent, time.Now())
+			return nil
+		default:
+			return fmt.Errorf("unknown event %q: expected %s or %s", recordSessionEvent, manager.SessionEventClosed, manager.SessionEventDetached)
+		}
+
 		currentSession, err := engine.GetCurrentSession(ctx)
 		if err != nil || currentSession == "" {
 			return nil
@@ -42,10 +75,13 @@ var recordSessionCmd = &cobra.Command{
 		}
 
 		_ = mgr.RecordProjectAccess(sessionPath)
+		_ = mgr.RecordSessionSwitch(currentSession, sessionPath)
 		return nil
 	},
 }
 
 func init() {
+	recordSessionCmd.Flags().StringVar(&recordSessionEvent, "event", "", "Record a session end instead of a switch: closed or detached")
+	recordSessionCmd.Flags().StringVar(&recordSessionName, "session", "", "Session the event applies to")
 	rootCmd.AddCommand(recordSessionCmd)
 }
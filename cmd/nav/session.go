@@ -9,6 +9,9 @@ import (
 	"github.com/grovetools/core/pkg/mux"
 	"github.com/grovetools/core/tui/theme"
 	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/tmux"
 )
 
 var ulogSession = grovelogging.NewUnifiedLogger("nav.session")
@@ -16,7 +19,7 @@ var ulogSession = grovelogging.NewUnifiedLogger("nav.session")
 var sessionCmd = &cobra.Command{
 	Use:   "session",
 	Short: "Manage tmux sessions",
-	Long:  "Commands for managing tmux sessions including checking existence, killing sessions, and capturing pane content.",
+	Long:  "Commands for managing tmux sessions including checking existence, killing sessions, capturing pane content, and saving/restoring sessions.",
 }
 
 var sessionExistsCmd = &cobra.Command{
@@ -69,11 +72,18 @@ var sessionKillCmd = &cobra.Command{
 			return fmt.Errorf("failed to detect mux engine: %w", err)
 		}
 
+		// Read the path before the session (and its path) is gone.
+		path := manager.SessionPath(sessionName)
+
 		err = engine.KillSession(ctx, sessionName)
 		if err != nil {
 			return fmt.Errorf("failed to kill session: %w", err)
 		}
 
+		if mgr, err := tmux.NewManager(configDir); err == nil {
+			mgr.RunHooksReportingErrors(ctx, manager.HookKill, sessionName, path, false)
+		}
+
 		ulogSession.Success("Session killed").
 			Field("session", sessionName).
 			Pretty(fmt.Sprintf("%s Session '%s' killed", theme.IconSuccess, sessionName)).
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/workspace"
+	tablecomponent "github.com/grovetools/core/tui/components/table"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var (
+	sessionListJSON    bool
+	sessionSendLiteral bool
+	sessionSendEnter   bool
+	sessionNewSwitch   bool
+)
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List running sessions with their nav mappings",
+	Long: `List running sessions with their project path, mapped key and group,
+attached clients, window count, and created/last-activity times.
+
+Use --json for scripting.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		sessions, err := mgr.ListSessionInfo()
+		if err != nil {
+			return err
+		}
+
+		if sessionListJSON {
+			if sessions == nil {
+				sessions = []manager.SessionInfo{}
+			}
+			data, err := json.MarshalIndent(sessions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal sessions to JSON: %w", err)
+			}
+			ulogSession.Info("Session list").
+				Field("format", "json").
+				Field("session_count", len(sessions)).
+				Pretty(string(data)).
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+
+		if len(sessions) == 0 {
+			ulogSession.Info("No running sessions").
+				Pretty(theme.IconInfo + " No running sessions").
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+
+		var rows [][]string
+		for _, s := range sessions {
+			mapping := ""
+			if s.Key != "" {
+				mapping = s.Key
+				if s.Group != "default" {
+					mapping = s.Group + "/" + s.Key
+				}
+			}
+			rows = append(rows, []string{
+				theme.DefaultTheme.Highlight.Render(s.Name),
+				mapping,
+				s.Path,
+				strconv.Itoa(s.Attached),
+				strconv.Itoa(s.Windows),
+				formatSessionTime(s.Created),
+				formatSessionTime(s.LastActivity),
+			})
+		}
+		t := tablecomponent.NewStyledTable().
+			Headers("Session", "Key", "Path", "Clients", "Windows", "Created", "Last Activity").
+			Rows(rows...)
+
+		ulogSession.Info("Session list").
+			Field("session_count", len(sessions)).
+			Pretty(t.String()).
+			PrettyOnly().
+			Emit()
+		return nil
+	},
+}
+
+var sessionRenameCmd = &cobra.Command{
+	Use:   "rename <session-name> <new-name>",
+	Short: "Rename a running session",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldName, newName := args[0], args[1]
+		if newName == "" || strings.ContainsAny(newName, ".:") {
+			return fmt.Errorf("invalid session name %q: tmux does not allow '.' or ':'", newName)
+		}
+
+		output, err := tmux.Command("rename-session", "-t", "="+oldName, newName).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to rename session: %s", strings.TrimSpace(string(output)))
+		}
+
+		ulogSession.Success("Session renamed").
+			Field("session", oldName).
+			Field("new_name", newName).
+			Pretty(fmt.Sprintf("%s Session '%s' renamed to '%s'", theme.IconSuccess, oldName, newName)).
+			PrettyOnly().
+			Emit()
+		return nil
+	},
+}
+
+var sessionSendCmd = &cobra.Command{
+	Use:   "send <target> <keys...>",
+	Short: "Send keys to a session, window, or pane",
+	Long: `Send keys to a target (session-name, session-name:window.pane, etc.).
+
+Keys are passed to tmux send-keys, so names like Enter or C-c are
+interpreted. Use --literal to send text verbatim and --enter to press Enter
+afterwards.
+
+  nav session send api 'go test ./...' Enter
+  nav session send api:0.1 --literal --enter 'echo "$HOME"'`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+
+		sendArgs := []string{"send-keys", "-t", target}
+		if sessionSendLiteral {
+			sendArgs = append(sendArgs, "-l")
+		}
+		sendArgs = append(sendArgs, args[1:]...)
+		if output, err := tmux.Command(sendArgs...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to send keys: %s", strings.TrimSpace(string(output)))
+		}
+		if sessionSendEnter {
+			if output, err := tmux.Command("send-keys", "-t", target, "Enter").CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to send Enter: %s", strings.TrimSpace(string(output)))
+			}
+		}
+		return nil
+	},
+}
+
+var sessionNewCmd = &cobra.Command{
+	Use:   "new [path]",
+	Short: "Create a session for a project using sessionize naming",
+	Long: `Create a detached session for the project at path (default: current
+directory). The session is named exactly as 'nav sessionize' would name it,
+and configured layouts, env, and hooks are applied. If the session already
+exists it is left alone. The session name is printed on stdout.
+
+Use --switch to switch to (or attach) the session afterwards.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		node, err := workspace.GetProjectByPath(path)
+		if err != nil {
+			return fmt.Errorf("failed to get project info for path %s: %w", path, err)
+		}
+		project := &manager.SessionizeProject{WorkspaceNode: node}
+
+		if sessionNewSwitch {
+			_ = mgr.RecordProjectAccess(project.Path)
+			return sessionizeProject(mgr, project)
+		}
+
+		sessionName := mgr.SessionName(node)
+		ctx := context.Background()
+		if err := tmux.Command("has-session", "-t", "="+sessionName).Run(); err != nil {
+			if err := mgr.LaunchSession(ctx, sessionName, project.Path); err != nil {
+				return err
+			}
+			mgr.RunHooksReportingErrors(ctx, manager.HookCreate, sessionName, project.Path, false)
+		}
+
+		fmt.Fprintln(os.Stdout, sessionName)
+		return nil
+	},
+}
+
+// formatSessionTime renders a session timestamp for the list table.
+func formatSessionTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func init() {
+	sessionListCmd.Flags().BoolVar(&sessionListJSON, "json", false, "Output sessions as JSON")
+	sessionSendCmd.Flags().BoolVarP(&sessionSendLiteral, "literal", "l", false, "Send keys literally instead of as key names")
+	sessionSendCmd.Flags().BoolVar(&sessionSendEnter, "enter", false, "Press Enter after sending the keys")
+	sessionNewCmd.Flags().BoolVar(&sessionNewSwitch, "switch", false, "Switch to (or attach) the session after creating it")
+
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionRenameCmd)
+	sessionCmd.AddCommand(sessionSendCmd)
+	sessionCmd.AddCommand(sessionNewCmd)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grovetools/core/pkg/mux"
+	tablecomponent "github.com/grovetools/core/tui/components/table"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var (
+	sessionPruneIdle   string
+	sessionPruneDryRun bool
+	sessionPruneJSON   bool
+)
+
+// pruneResult is one session reported by `nav session prune --json`.
+type pruneResult struct {
+	manager.PruneCandidate
+	Killed bool   `json:"killed"`
+	Error  string `json:"error,omitempty"`
+}
+
+var sessionPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Kill sessions that have been idle for a while",
+	Long: `Kill sessions that have not been used for at least --idle.
+
+A session's last use is the later of its tmux activity time and the last
+time its project was opened through nav. Idle sessions are kept when a
+client is attached, when they are mapped to a locked key, or when any pane
+is running something other than a shell. on_kill hooks run for every
+session that is killed.
+
+  nav session prune --idle 6h --dry-run
+  nav session prune --idle 24h`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idle, err := time.ParseDuration(sessionPruneIdle)
+		if err != nil {
+			return fmt.Errorf("invalid idle duration: %w", err)
+		}
+		if idle <= 0 {
+			return fmt.Errorf("--idle must be positive")
+		}
+
+		ctx := context.Background()
+		engine, err := mux.DetectMuxEngine(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to detect mux engine: %w", err)
+		}
+		if mux.ActiveMux() == mux.MuxTuimux {
+			return fmt.Errorf("session prune is only supported with tmux")
+		}
+
+		mgr, err := tmux.NewManager(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize manager: %w", err)
+		}
+		candidates, err := mgr.FindIdleSessions(idle, time.Now())
+		if err != nil {
+			return err
+		}
+
+		results := make([]pruneResult, 0, len(candidates))
+		for _, c := range candidates {
+			r := pruneResult{PruneCandidate: c}
+			if c.Skip == "" && !sessionPruneDryRun {
+				if err := engine.KillSession(ctx, c.Name); err != nil {
+					r.Error = err.Error()
+				} else {
+					r.Killed = true
+					mgr.RunHooksReportingErrors(ctx, manager.HookKill, c.Name, c.Path, sessionPruneJSON)
+				}
+			}
+			results = append(results, r)
+		}
+
+		return reportPrune(results, idle)
+	},
+}
+
+// reportPrune prints what prune killed (or would kill) and kept.
+func reportPrune(results []pruneResult, idle time.Duration) error {
+	killed := 0
+	for _, r := range results {
+		if r.Killed {
+			killed++
+		}
+	}
+
+	if sessionPruneJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal prune results to JSON: %w", err)
+		}
+		ulogSession.Info("Session prune").
+			Field("format", "json").
+			Field("killed_count", killed).
+			Pretty(string(data)).
+			PrettyOnly().
+			Emit()
+		return nil
+	}
+
+	if len(results) == 0 {
+		ulogSession.Info("No idle sessions").
+			Field("idle", idle.String()).
+			Pretty(fmt.Sprintf("%s No sessions idle for %s", theme.IconInfo, idle)).
+			PrettyOnly().
+			Emit()
+		return nil
+	}
+
+	var rows [][]string
+	for _, r := range results {
+		var status string
+		switch {
+		case r.Skip != "":
+			status = theme.DefaultTheme.Muted.Render("kept: " + r.Skip)
+		case r.Error != "":
+			status = theme.DefaultTheme.Error.Render("error: " + r.Error)
+		case r.Killed:
+			status = theme.DefaultTheme.Success.Render("killed")
+		default:
+			status = "would kill"
+		}
+		rows = append(rows, []string{
+			theme.DefaultTheme.Highlight.Render(r.Name),
+			r.Path,
+			formatSessionTime(r.LastUsed),
+			status,
+		})
+	}
+	t := tablecomponent.NewStyledTable().
+		Headers("Session", "Path", "Last Used", "Status").
+		Rows(rows...)
+
+	summary := fmt.Sprintf("%s Killed %d idle session(s)", theme.IconSuccess, killed)
+	if sessionPruneDryRun {
+		summary = fmt.Sprintf("%s Dry run: no sessions were killed", theme.IconInfo)
+	}
+	ulogSession.Info("Session prune").
+		Field("idle", idle.String()).
+		Field("dry_run", sessionPruneDryRun).
+		Field("killed_count", killed).
+		Pretty(t.String() + "\n" + summary).
+		PrettyOnly().
+		Emit()
+
+	for _, r := range results {
+		if r.Error != "" {
+			return fmt.Errorf("failed to kill some sessions")
+		}
+	}
+	return nil
+}
+
+func init() {
+	sessionPruneCmd.Flags().StringVar(&sessionPruneIdle, "idle", "6h", "Minimum idle time before a session is pruned")
+	sessionPruneCmd.Flags().BoolVar(&sessionPruneDryRun, "dry-run", false, "Show what would be killed without killing anything")
+	sessionPruneCmd.Flags().BoolVar(&sessionPruneJSON, "json", false, "Output the result as JSON")
+
+	sessionCmd.AddCommand(sessionPruneCmd)
+}
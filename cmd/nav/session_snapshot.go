@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	tmuxclient "github.com/grovetools/core/pkg/tmux"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/tmux"
+)
+
+var (
+	sessionSaveAll      bool
+	sessionSaveInterval time.Duration
+)
+
+var sessionSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save running sessions so they can be restored later",
+	Long: `Record the windows, pane layout, pane working directories and foreground
+commands of running sessions into the nav state directory.
+
+By default only sessions nav created (or whose root is a mapped project) are
+saved. Use --all to save every session on the server.
+
+With --interval, save keeps running and re-saves on that period, which makes a
+simple autosave, e.g. from tmux.conf:
+
+  run-shell -b 'nav session save --interval 5m'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sessionSaveInterval <= 0 {
+			_, err := saveSessions(context.Background())
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		ticker := time.NewTicker(sessionSaveInterval)
+		defer ticker.Stop()
+		for {
+			if _, err := saveSessions(ctx); err != nil {
+				ulogSession.Error("Autosave failed").
+					Err(err).
+					Emit()
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+var sessionRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Re-create sessions recorded by 'nav session save'",
+	Long: `Re-create the sessions recorded by 'nav session save'. Sessions that already
+exist are skipped, so restore is safe to run more than once.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snap, err := manager.LoadSnapshot()
+		if err != nil {
+			return fmt.Errorf("failed to load session snapshot: %w", err)
+		}
+		if len(snap.Sessions) == 0 {
+			ulogSession.Info("No saved sessions").
+				Pretty(theme.IconInfo + " No saved sessions to restore").
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+
+		var restored, skipped []string
+		var failed int
+		for _, s := range snap.Sessions {
+			if err := tmux.Command("has-session", "-t", "="+s.Name).Run(); err == nil {
+				skipped = append(skipped, s.Name)
+				continue
+			}
+			if err := manager.RestoreSession(s); err != nil {
+				failed++
+				ulogSession.Error("Failed to restore session").
+					Field("session", s.Name).
+					Err(err).
+					Pretty(fmt.Sprintf("%s %s: %v", theme.IconError, s.Name, err)).
+					PrettyOnly().
+					Emit()
+				continue
+			}
+			restored = append(restored, s.Name)
+		}
+
+		ulogSession.Success("Sessions restored").
+			Field("restored", restored).
+			Field("skipped", skipped).
+			Field("failed", failed).
+			Pretty(fmt.Sprintf("%s Restored %d session(s), skipped %d already running%s",
+				theme.IconSuccess, len(restored), len(skipped), formatNames(skipped))).
+			PrettyOnly().
+			Emit()
+
+		if failed > 0 {
+			return fmt.Errorf("%d session(s) could not be restored", failed)
+		}
+		return nil
+	},
+}
+
+// saveSessions captures the selected sessions and writes the snapshot.
+// Sessions that cannot be captured are skipped. A server with no sessions
+// (e.g. one that just died) never overwrites the previous snapshot.
+func saveSessions(ctx context.Context) (int, error) {
+	client, err := tmuxclient.NewClient()
+	if err != nil {
+		return 0, err
+	}
+	names, err := client.ListSessions(ctx)
+	if err != nil || len(names) == 0 {
+		ulogSession.Info("No running sessions").
+			Pretty(theme.IconInfo + " No running sessions; keeping previous snapshot").
+			PrettyOnly().
+			Emit()
+		return 0, nil
+	}
+
+	var mgr *tmux.Manager
+	if !sessionSaveAll {
+		mgr, err = tmux.NewManager(configDir)
+		if err != nil {
+			return 0, fmt.Errorf("failed to initialize manager: %w", err)
+		}
+	}
+
+	driver := newWindowsDriver(client)
+	snap := manager.SessionSnapshotFile{SavedAt: time.Now()}
+	for _, name := range names {
+		if mgr != nil && !mgr.IsNavSession(name) {
+			continue
+		}
+		s, err := manager.CaptureSession(ctx, driver, name)
+		if err != nil {
+			// A session closing mid-save must not cost the others their snapshot.
+			ulogSession.Warn("Failed to capture session").
+				Field("session", name).
+				Err(err).
+				Pretty(fmt.Sprintf("%s Skipping %s: %v", theme.IconWarning, name, err)).
+				PrettyOnly().
+				Emit()
+			continue
+		}
+		snap.Sessions = append(snap.Sessions, s)
+	}
+	if len(snap.Sessions) == 0 {
+		ulogSession.Info("No nav sessions to save").
+			Pretty(theme.IconInfo + " No nav sessions running (use --all to save every session)").
+			PrettyOnly().
+			Emit()
+		return 0, nil
+	}
+
+	if err := snap.Save(); err != nil {
+		return 0, fmt.Errorf("failed to write session snapshot: %w", err)
+	}
+
+	saved := make([]string, 0, len(snap.Sessions))
+	for _, s := range snap.Sessions {
+		saved = append(saved, s.Name)
+	}
+	ulogSession.Success("Sessions saved").
+		Field("sessions", saved).
+		Field("path", manager.SnapshotPath()).
+		Pretty(fmt.Sprintf("%s Saved %d session(s) to %s", theme.IconSuccess, len(saved), manager.SnapshotPath())).
+		PrettyOnly().
+		Emit()
+	return len(saved), nil
+}
+
+// formatNames renders a parenthesised name list for summary lines.
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(names, ", ") + ")"
+}
+
+func init() {
+	sessionSaveCmd.Flags().BoolVar(&sessionSaveAll, "all", false, "Save every session, not only sessions nav manages")
+	sessionSaveCmd.Flags().DurationVar(&sessionSaveInterval, "interval", 0, "Keep running and save on this interval (e.g. 5m)")
+
+	sessionCmd.AddCommand(sessionSaveCmd)
+	sessionCmd.AddCommand(sessionRestoreCmd)
+}
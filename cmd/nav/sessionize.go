@@ -44,7 +44,10 @@ var sessionizeCmd = &cobra.Command{
 				return fmt.Errorf("failed to get project info for path %s: %w", args[0], err)
 			}
 			project := &manager.SessionizeProject{WorkspaceNode: node}
-			return sessionizeProject(project)
+			return sessionizeProjectWindow(mgr, project, sessionizeWindow)
+		}
+		if sessionizeWindow != "" {
+			return fmt.Errorf("--window requires a project path")
 		}
 
 		// Otherwise, show the interactive project picker
@@ -96,27 +99,77 @@ var sessionizeCmd = &cobra.Command{
 		}
 
 		// Use unified nav TUI with lazy initialization
-		return runNavTUIWithTab(navapp.TabSessionize, NavTUIOptions{CwdFocusPath: cwdFocusPath})
+		return runNavTUIWithTab(navapp.TabSessionize, NavTUIOptions{CwdFocusPath: cwdFocusPath, Filter: sessionizeFilter})
 	},
 }
 
+// Flags for sessionize
+var (
+	sessionizeWindow string
+	sessionizeFilter string
+)
+
+func init() {
+	sessionizeCmd.Flags().StringVar(&sessionizeWindow, "window", "", "Select this window (name or index) in the project session, creating it if missing")
+	sessionizeCmd.Flags().StringVar(&sessionizeFilter, "filter", "", "Initial filter for the interactive picker")
+}
+
 // sessionizeProject creates or switches to a mux session for the given project.
-func sessionizeProject(project *manager.SessionizeProject) error {
+// New tmux sessions are created through mgr so configured layouts are applied.
+func sessionizeProject(mgr *navtmux.Manager, project *manager.SessionizeProject) error {
+	return sessionizeProjectWindow(mgr, project, "")
+}
+
+// sessionizeProjectWindow is sessionizeProject that also selects window in
+// the session (tmux only), creating it in the project directory if needed.
+func sessionizeProjectWindow(mgr *navtmux.Manager, project *manager.SessionizeProject, window string) error {
 	if project == nil {
 		return fmt.Errorf("no project selected")
 	}
 
-	sessionName := project.Identifier("_")
+	sessionName := mgr.SessionName(project.WorkspaceNode)
 	absPath := project.Path
 
 	switch mux.ActiveMux() {
 	case mux.MuxTuimux:
+		if window != "" {
+			ulogSessionize.Warn("Window selection is not supported under tuimux").
+				Field("window", window).
+				Emit()
+		}
 		return sessionizeViaTuimux(sessionName, absPath)
 	case mux.MuxTmux:
-		return sessionizeViaTmux(sessionName, absPath)
+		return sessionizeViaTmux(mgr, sessionName, absPath, window)
 	default:
 		// Not in any mux — launch a tmux session interactively via exec.
-		cmd := exec.Command("tmux", "new-session", "-s", sessionName, "-c", absPath)
+		// Sessions with a layout or env (or a window to select) are built
+		// detached first, then attached.
+		setup, err := mgr.HasSessionSetup(absPath)
+		if err != nil {
+			return err
+		}
+		if !setup && window == "" {
+			cmd := exec.Command("tmux", "new-session", "-s", sessionName, "-c", absPath)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+		ctx := context.Background()
+		if err := navtmux.Command("has-session", "-t", "="+sessionName).Run(); err != nil {
+			if err := mgr.LaunchSession(ctx, sessionName, absPath); err != nil {
+				return err
+			}
+			mgr.RunHooksReportingErrors(ctx, manager.HookCreate, sessionName, absPath, false)
+		}
+		if window != "" {
+			if err := selectSessionWindow(mgr, sessionName, window, absPath); err != nil {
+				return err
+			}
+		}
+		// Attaching blocks until detach, so on_switch runs first.
+		mgr.RunHooksReportingErrors(ctx, manager.HookSwitch, sessionName, absPath, false)
+		cmd := navtmux.Command("attach-session", "-t", sessionName)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -132,6 +185,7 @@ func sessionizeViaTuimux(sessionName, absPath string) error {
 	}
 
 	// SwitchSession auto-creates within the current TUI if the session doesn't exist.
+	// Layouts and env are tmux-only; tuimux sessions always start bare.
 	if err := engine.SwitchSession(ctx, sessionName, absPath); err != nil {
 		return fmt.Errorf("failed to switch to session: %w", err)
 	}
@@ -144,7 +198,7 @@ func sessionizeViaTuimux(sessionName, absPath string) error {
 	return nil
 }
 
-func sessionizeViaTmux(sessionName, absPath string) error {
+func sessionizeViaTmux(mgr *navtmux.Manager, sessionName, absPath, window string) error {
 	ctx := context.Background()
 	engine, err := mux.DetectMuxEngine(ctx)
 	if err != nil {
@@ -157,18 +211,26 @@ func sessionizeViaTmux(sessionName, absPath string) error {
 	}
 
 	if !exists {
-		opts := mux.LaunchOptions{
-			SessionName:      sessionName,
-			WorkingDirectory: absPath,
+		if err := mgr.LaunchSession(ctx, sessionName, absPath); err != nil {
+			return err
 		}
-		if err := engine.Launch(ctx, opts); err != nil {
-			return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if window != "" {
+		if err := selectSessionWindow(mgr, sessionName, window, absPath); err != nil {
+			return err
 		}
 	}
 
 	if err := engine.SwitchSession(ctx, sessionName, ""); err != nil {
 		return fmt.Errorf("failed to switch to session: %w", err)
 	}
+	// Hooks run once the client is in the session, so a slow hook never
+	// holds up the switch.
+	if !exists {
+		mgr.RunHooksReportingErrors(ctx, manager.HookCreate, sessionName, absPath, false)
+	}
+	mgr.RunHooksReportingErrors(ctx, manager.HookSwitch, sessionName, absPath, false)
 
 	if te, ok := engine.(mux.MuxTUIEngine); ok {
 		_ = te.ClosePopup(ctx)
@@ -177,6 +239,34 @@ func sessionizeViaTmux(sessionName, absPath string) error {
 	return nil
 }
 
+// selectSessionWindow makes window the current window of sessionName,
+// creating it when no window by that name or index exists: from the window
+// of that name in the project's layout when there is one, otherwise as a
+// plain window in dir.
+func selectSessionWindow(mgr *navtmux.Manager, sessionName, window, dir string) error {
+	target := "=" + sessionName + ":" + window
+	if err := navtmux.Command("select-window", "-t", target).Run(); err == nil {
+		return nil
+	}
+	template, err := mgr.WindowTemplate(dir, window)
+	if err != nil {
+		return err
+	}
+	if template != nil {
+		if err := navtmux.AddLayoutWindow(sessionName, dir, *template); err != nil {
+			return fmt.Errorf("failed to create window %q: %w", window, err)
+		}
+		if err := navtmux.Command("select-window", "-t", target).Run(); err != nil {
+			return fmt.Errorf("failed to select window %q: %w", window, err)
+		}
+		return nil
+	}
+	if out, err := navtmux.Command("new-window", "-t", "="+sessionName+":", "-n", window, "-c", dir).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create window %q: %s", window, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 func handleFirstRunSetup(configDir string, _ *navtmux.Manager) error {
 	// Welcome message
 	ulogSessionize.Info("First run setup").
@@ -338,10 +428,10 @@ func handleFirstRunSetup(configDir string, _ *navtmux.Manager) error {
 	ulogSessionize.Success("Configuration saved").
 		Field("config_path", configPath).
 		Field("directory_count", len(searchPaths)).
-		Pretty(fmt.Sprintf("\n%s Configuration saved to: %s\n%s Added %d project director%s\n\n%s Setup complete! Run 'nav sz' to start using the sessionizer.",
+		Pretty(fmt.Sprintf("\n%s Configuration saved to: %s\n%s Added %d project director%s\n\n%s Setup complete! Run 'nav sz' to start using the sessionizer.\n%s Run 'nav tmux install' to load nav's hotkeys in tmux.",
 			theme.IconSuccess, configPath,
 			theme.IconSuccess, len(searchPaths), directorySuffix,
-			theme.IconSuccess)).
+			theme.IconSuccess, theme.IconInfo)).
 		PrettyOnly().
 		Emit()
 	return nil
@@ -1,28 +1,30 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"path/filepath"
+	"slices"
 
 	grovelogging "github.com/grovetools/core/logging"
-	"github.com/grovetools/core/pkg/models"
-	"github.com/grovetools/core/pkg/mux"
-	"github.com/grovetools/core/tui/theme"
+	"github.com/grovetools/core/pkg/workspace"
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/nav/internal/manager"
 	"github.com/grovetools/nav/pkg/tmux"
 )
 
 var ulogStart = grovelogging.NewUnifiedLogger("nav.start")
 
+var startGroup string
+
 var startCmd = &cobra.Command{
 	Use:   "start <key>",
-	Short: "Start a pre-configured tmux session",
-	Long: `Start a tmux session using configuration from tmux-sessions.yaml.
+	Short: "Start or switch to the session mapped to a key",
+	Long: `Start the session for a mapped key, or switch to it if it is already running.
 
-The session will be created with the name 'grove-<key>' and will automatically
-change to the configured directory for that session.`,
+The key is looked up in the default group, or in the group given with --group.
+The session is named exactly as 'nav sessionize' would name it, so a project
+never ends up with two sessions, and nav switches to it (inside tmux) or
+attaches to it (outside tmux) the same way sessionize does.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
@@ -31,87 +33,48 @@ change to the configured directory for that session.`,
 		if err != nil {
 			return fmt.Errorf("failed to initialize manager: %w", err)
 		}
+
+		group := startGroup
+		if group == "" {
+			group = "default"
+		}
+		if !slices.Contains(mgr.GetAllGroups(), group) {
+			return fmt.Errorf("group '%s' does not exist", group)
+		}
+		mgr.SetActiveGroup(group)
+
 		sessions, err := mgr.GetSessions()
 		if err != nil {
 			return fmt.Errorf("failed to load sessions: %w", err)
 		}
 
-		// Find the session config
-		var session *models.TmuxSession
+		var path string
 		for _, s := range sessions {
 			if s.Key == key {
-				session = &s
+				path = s.Path
 				break
 			}
 		}
-
-		if session == nil {
-			return fmt.Errorf("no session configured for key '%s'", key)
+		if path == "" {
+			return fmt.Errorf("no session configured for key '%s' in group '%s'", key, group)
 		}
 
-		ctx := context.Background()
-		engine, err := mux.DetectMuxEngine(ctx)
+		node, err := workspace.GetProjectByPath(path)
 		if err != nil {
-			return fmt.Errorf("failed to detect mux engine: %w", err)
-		}
-
-		// Prepare session name
-		sessionName := fmt.Sprintf("grove-%s", key)
-
-		// Check if session already exists
-		exists, err := engine.SessionExists(ctx, sessionName)
-		if err != nil {
-			return fmt.Errorf("failed to check session existence: %w", err)
-		}
-
-		if exists {
-			ulogStart.Info("Session already exists").
-				Field("session", sessionName).
-				Field("key", key).
-				Pretty(fmt.Sprintf("%s Session '%s' already exists. Attaching...\n\nTo attach manually, run:\n  tmux attach-session -t %s",
-					theme.IconInfo, sessionName, sessionName)).
-				PrettyOnly().
-				Emit()
-			return nil
-		}
-
-		// Determine working directory
-		workDir := session.Path
-		if workDir == "" && session.Repository != "" {
-			// Try to use repository name as directory under home
-			workDir = filepath.Join("~", session.Repository)
-		}
-
-		opts := mux.LaunchOptions{
-			SessionName:      sessionName,
-			WorkingDirectory: workDir,
-			WindowName:       session.Repository,
-		}
-
-		err = engine.Launch(ctx, opts)
-		if err != nil {
-			return fmt.Errorf("failed to launch session: %w", err)
-		}
-
-		prettyMsg := fmt.Sprintf("%s Session '%s' started for %s", theme.IconSuccess, sessionName, session.Description)
-		if workDir != "" {
-			prettyMsg += fmt.Sprintf("\nWorking directory: %s", workDir)
+			return fmt.Errorf("failed to get project info for path %s: %w", path, err)
 		}
-		prettyMsg += fmt.Sprintf("\n\nTo attach to this session, run:\n  tmux attach-session -t %s", sessionName)
+		_ = mgr.RecordProjectAccess(path)
 
-		ulogStart.Success("Session started").
-			Field("session", sessionName).
+		ulogStart.Debug("Starting mapped session").
 			Field("key", key).
-			Field("working_dir", workDir).
-			Field("description", session.Description).
-			Pretty(prettyMsg).
-			PrettyOnly().
+			Field("group", group).
+			Field("path", path).
 			Emit()
 
-		return nil
+		return sessionizeProject(mgr, &manager.SessionizeProject{WorkspaceNode: node})
 	},
 }
 
 func init() {
-	// The start command uses the global config flags from rootCmd
+	startCmd.Flags().StringVarP(&startGroup, "group", "g", "", "Workspace group to look the key up in (default: default)")
 }
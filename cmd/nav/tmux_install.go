@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	grovelogging "github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/tui/theme"
+	"github.com/spf13/cobra"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+)
+
+var ulogTmux = grovelogging.NewUnifiedLogger("nav.tmux")
+
+var tmuxCmd = &cobra.Command{
+	Use:   "tmux",
+	Short: "Manage nav's integration with tmux.conf",
+}
+
+var tmuxInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Source nav's generated bindings from tmux.conf",
+	Long: `Add a marked block to your tmux.conf that sources nav's generated
+bindings, then reload the running tmux server.
+
+The block goes into ~/.tmux.conf, or $XDG_CONFIG_HOME/tmux/tmux.conf when
+that is the file tmux reads. Running install again updates the block in
+place; the original file is backed up next to it before any change.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confPath, err := navbindings.UserTmuxConfPath()
+		if err != nil {
+			return err
+		}
+		sourcePath := navbindings.TmuxConfPath(navbindings.Options{CacheDir: paths.CacheDir()})
+		changed, backup, err := navbindings.InstallTmuxConf(confPath, sourcePath)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			ulogTmux.Info("tmux.conf already set up").
+				Field("path", confPath).
+				Pretty(fmt.Sprintf("%s %s already sources nav's bindings", theme.IconInfo, confPath)).
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+		reportTmuxConfChange("Installed nav block", confPath, backup)
+		return nil
+	},
+}
+
+var tmuxUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove nav's block from tmux.conf",
+	Long: `Remove the block added by 'nav tmux install' from your tmux.conf and
+reload the running tmux server. The file is backed up first. Bindings that
+are already loaded stay active until tmux restarts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confPath, err := navbindings.UserTmuxConfPath()
+		if err != nil {
+			return err
+		}
+		changed, backup, err := navbindings.UninstallTmuxConf(confPath)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			ulogTmux.Info("No nav block in tmux.conf").
+				Field("path", confPath).
+				Pretty(fmt.Sprintf("%s No nav block in %s", theme.IconInfo, confPath)).
+				PrettyOnly().
+				Emit()
+			return nil
+		}
+		reportTmuxConfChange("Removed nav block", confPath, backup)
+		return nil
+	},
+}
+
+// reportTmuxConfChange reloads tmux after tmux.conf was edited and reports
+// the change, the backup, and whether the reload worked.
+func reportTmuxConfChange(summary, confPath, backup string) {
+	pretty := fmt.Sprintf("%s %s in %s", theme.IconSuccess, summary, confPath)
+	if backup != "" {
+		pretty += fmt.Sprintf("\n%s Backup saved to %s", theme.IconInfo, backup)
+	}
+	reloadErr := reloadTmuxConfig()
+	if reloadErr != nil {
+		pretty += fmt.Sprintf("\n%s tmux not reloaded (%v); run 'tmux source-file %s'", theme.IconWarning, reloadErr, confPath)
+	} else {
+		pretty += fmt.Sprintf("\n%s Reloaded tmux", theme.IconSuccess)
+	}
+	ulogTmux.Success(summary).
+		Field("path", confPath).
+		Field("backup", backup).
+		Field("reloaded", reloadErr == nil).
+		Pretty(pretty).
+		PrettyOnly().
+		Emit()
+}
+
+func init() {
+	tmuxCmd.AddCommand(tmuxInstallCmd)
+	tmuxCmd.AddCommand(tmuxUninstallCmd)
+
+	rootCmd.AddCommand(tmuxCmd)
+}
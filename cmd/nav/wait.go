@@ -2,13 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	grovelogging "github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/mux"
 	"github.com/grovetools/core/tui/theme"
 	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nav/pkg/tmux"
 )
 
 var ulogWait = grovelogging.NewUnifiedLogger("nav.wait")
@@ -16,20 +23,67 @@ var ulogWait = grovelogging.NewUnifiedLogger("nav.wait")
 var (
 	waitPollInterval string
 	waitTimeout      string
+	waitPane         string
+	waitExit         bool
+	waitMatch        string
+	waitAny          bool
+	waitAll          bool
+	waitJSON         bool
 )
 
+// waitTargetResult is the per-target outcome reported by `nav wait --json`.
+type waitTargetResult struct {
+	Target     string `json:"target"`
+	Done       bool   `json:"done"`
+	ExitStatus *int   `json:"exit_status,omitempty"`
+	Match      string `json:"match,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// waitResult is the overall outcome reported by `nav wait --json`.
+type waitResult struct {
+	Condition string             `json:"condition"`
+	Mode      string             `json:"mode"`
+	Satisfied bool               `json:"satisfied"`
+	TimedOut  bool               `json:"timed_out"`
+	Elapsed   string             `json:"elapsed"`
+	Targets   []waitTargetResult `json:"targets"`
+}
+
+// waitCheck polls a single target once and reports whether its condition
+// has been met. A returned error is final for that target.
+type waitCheck func(ctx context.Context, target string) (waitTargetResult, error)
+
 var waitCmd = &cobra.Command{
-	Use:   "wait <session-name>",
-	Short: "Wait for a tmux session to close",
-	Long: `Block until the specified tmux session closes. Useful for scripting and automation.
-	
-The command will poll at regular intervals to check if the session still exists.
-When the session closes, the command exits with status 0.
-If the timeout is reached or an error occurs, it exits with non-zero status.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		sessionName := args[0]
+	Use:   "wait [session-name...]",
+	Short: "Wait for sessions to close, panes to exit, or output to appear",
+	Long: `Block until a condition is met. Useful for scripting and automation.
+
+By default nav waits for the given sessions to close. With several sessions,
+--all (the default) waits for every session and --any for the first one.
 
+  # Wait for a session to close
+  nav wait build
+
+  # Wait for a pane's command to exit and exit with its status
+  nav wait --pane build:0.1 --exit
+
+  # Wait until pane output matches a pattern
+  nav wait --pane build:0.1 --match 'listening on :\d+'
+
+  # Wait for the first of several sessions to print "ready"
+  nav wait --any --match ready api web worker
+
+The command polls at --poll-interval. It exits with status 0 once the
+condition is met (or with the pane's exit status for --exit), and non-zero
+on timeout or error. --json prints a structured result.
+
+--exit keeps the pane open after its command exits (remain-on-exit) so the
+status can be read, and restores the pane's own setting afterwards; the dead
+pane is left for you to read or close. A command that exits before nav wait
+starts watching has already closed its pane and is reported as gone, so
+start nav wait first or give the pane remain-on-exit yourself.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Parse durations
 		pollInterval, err := time.ParseDuration(waitPollInterval)
 		if err != nil {
@@ -41,6 +95,25 @@ If the timeout is reached or an error occurs, it exits with non-zero status.`,
 			return fmt.Errorf("invalid timeout: %w", err)
 		}
 
+		targets := args
+		switch {
+		case waitPane != "" && len(args) > 0:
+			return fmt.Errorf("--pane cannot be combined with session names")
+		case waitPane != "":
+			targets = []string{waitPane}
+		case len(args) == 0:
+			return fmt.Errorf("specify at least one session name or --pane")
+		}
+		if waitExit && waitPane == "" {
+			return fmt.Errorf("--exit requires --pane")
+		}
+		if waitExit && waitMatch != "" {
+			return fmt.Errorf("--exit and --match cannot be combined")
+		}
+		if waitAny && waitAll {
+			return fmt.Errorf("--any and --all cannot be combined")
+		}
+
 		// Create context with timeout
 		ctx := context.Background()
 		if timeout > 0 {
@@ -54,30 +127,209 @@ If the timeout is reached or an error occurs, it exits with non-zero status.`,
 			return fmt.Errorf("failed to detect mux engine: %w", err)
 		}
 
-		ulogWait.Progress("Waiting for session").
-			Field("session", sessionName).
-			Pretty(fmt.Sprintf("%s Waiting for session '%s' to close...", theme.IconRunning, sessionName)).
+		var condition string
+		var check waitCheck
+		var unwatch func()
+		switch {
+		case waitExit:
+			if mux.ActiveMux() == mux.MuxTuimux {
+				return fmt.Errorf("--exit is only supported with tmux")
+			}
+			condition = "exit"
+			unwatch, err = watchPaneExit(waitPane)
+			if err != nil {
+				return fmt.Errorf("failed to watch pane %s: %w", waitPane, err)
+			}
+			check = checkPaneExit
+		case waitMatch != "":
+			re, err := regexp.Compile(waitMatch)
+			if err != nil {
+				return fmt.Errorf("invalid --match pattern: %w", err)
+			}
+			condition = "match"
+			check = func(ctx context.Context, target string) (waitTargetResult, error) {
+				content, err := engine.CapturePane(ctx, target)
+				if err != nil {
+					return waitTargetResult{Target: target}, err
+				}
+				match := re.FindString(content)
+				return waitTargetResult{Target: target, Done: match != "", Match: match}, nil
+			}
+		default:
+			condition = "close"
+			check = func(ctx context.Context, target string) (waitTargetResult, error) {
+				exists, err := engine.SessionExists(ctx, target)
+				if err != nil {
+					return waitTargetResult{Target: target}, err
+				}
+				return waitTargetResult{Target: target, Done: !exists}, nil
+			}
+		}
+
+		mode := "all"
+		if waitAny {
+			mode = "any"
+		}
+
+		if !waitJSON {
+			ulogWait.Progress("Waiting").
+				Field("condition", condition).
+				Field("mode", mode).
+				Field("targets", targets).
+				Pretty(fmt.Sprintf("%s Waiting for %s (%s): %s...", theme.IconRunning, condition, mode, strings.Join(targets, ", "))).
+				PrettyOnly().
+				Emit()
+		}
+
+		start := time.Now()
+		result := pollWait(ctx, targets, check, waitAny, pollInterval)
+		if unwatch != nil {
+			// Before reportWait, which may exit with the pane's status.
+			unwatch()
+		}
+		result.Condition = condition
+		result.Mode = mode
+		result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+
+		return reportWait(result)
+	},
+}
+
+// pollWait polls every unfinished target until the any/all condition is
+// satisfied, a target fails, or ctx ends.
+func pollWait(ctx context.Context, targets []string, check waitCheck, matchAny bool, interval time.Duration) waitResult {
+	results := make([]waitTargetResult, len(targets))
+	for i, t := range targets {
+		results[i] = waitTargetResult{Target: t}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		doneCount := 0
+		for i := range results {
+			if !results[i].Done && results[i].Error == "" {
+				r, err := check(ctx, results[i].Target)
+				if err != nil {
+					if ctx.Err() != nil {
+						break
+					}
+					r.Error = err.Error()
+				}
+				results[i] = r
+			}
+			if results[i].Error != "" {
+				// A failed target can never satisfy the condition.
+				return waitResult{Targets: results}
+			}
+			if results[i].Done {
+				doneCount++
+			}
+		}
+		if (matchAny && doneCount > 0) || doneCount == len(results) {
+			return waitResult{Satisfied: true, Targets: results}
+		}
+
+		select {
+		case <-ctx.Done():
+			return waitResult{TimedOut: true, Targets: results}
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchPaneExit sets remain-on-exit on the pane so the exit status of its
+// command stays readable, and returns a func that restores the pane's own
+// setting. A pane whose command already exited without remain-on-exit is
+// gone by now; checkPaneExit reports it as such.
+func watchPaneExit(target string) (func(), error) {
+	output, err := tmux.Command("show-options", "-p", "-q", "-v", "-t", target, "remain-on-exit").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	prev := strings.TrimSpace(string(output))
+	if output, err := tmux.Command("set-option", "-p", "-t", target, "remain-on-exit", "on").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return func() {
+		if prev != "" {
+			_ = tmux.Command("set-option", "-p", "-t", target, "remain-on-exit", prev).Run()
+		} else {
+			_ = tmux.Command("set-option", "-p", "-u", "-t", target, "remain-on-exit").Run()
+		}
+	}, nil
+}
+
+// checkPaneExit reports whether the pane's command has exited. The pane
+// was set to remain-on-exit, so its exit status stays readable. The dead
+// pane is left in place.
+func checkPaneExit(_ context.Context, target string) (waitTargetResult, error) {
+	result := waitTargetResult{Target: target}
+	output, err := tmux.Command("display-message", "-p", "-t", target, "#{pane_dead} #{pane_dead_status}").CombinedOutput()
+	if err != nil {
+		return result, fmt.Errorf("pane %s is gone (its command may have exited before nav wait started): %s", target, strings.TrimSpace(string(output)))
+	}
+	dead, status, _ := strings.Cut(strings.TrimSpace(string(output)), " ")
+	if dead != "1" {
+		return result, nil
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return result, fmt.Errorf("pane %s exited without a status", target)
+	}
+	result.Done = true
+	result.ExitStatus = &code
+	return result, nil
+}
+
+// reportWait prints the result and maps it to the command's exit status.
+func reportWait(result waitResult) error {
+	if waitJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal wait result to JSON: %w", err)
+		}
+		ulogWait.Info("Wait result").
+			Field("format", "json").
+			Pretty(string(data)).
 			PrettyOnly().
 			Emit()
+	}
 
-		err = engine.WaitForSessionClose(ctx, sessionName, pollInterval)
-		if err != nil {
-			if err == context.DeadlineExceeded {
-				return fmt.Errorf("timeout waiting for session to close")
-			}
-			return fmt.Errorf("error waiting for session: %w", err)
+	for _, t := range result.Targets {
+		if t.Error != "" {
+			return fmt.Errorf("error waiting for %s: %s", t.Target, t.Error)
 		}
+	}
+	if result.TimedOut {
+		return fmt.Errorf("timeout waiting for %s", result.Condition)
+	}
 
-		ulogWait.Success("Session closed").
-			Field("session", sessionName).
-			Pretty(fmt.Sprintf("%s Session '%s' has closed", theme.IconSuccess, sessionName)).
+	if !waitJSON {
+		ulogWait.Success("Wait condition met").
+			Field("condition", result.Condition).
+			Field("elapsed", result.Elapsed).
+			Pretty(fmt.Sprintf("%s Condition '%s' met after %s", theme.IconSuccess, result.Condition, result.Elapsed)).
 			PrettyOnly().
 			Emit()
-		return nil
-	},
+	}
+
+	// Propagate the pane command's exit status.
+	if result.Condition == "exit" && len(result.Targets) == 1 && result.Targets[0].ExitStatus != nil {
+		if code := *result.Targets[0].ExitStatus; code != 0 {
+			os.Exit(code)
+		}
+	}
+	return nil
 }
 
 func init() {
-	waitCmd.Flags().StringVar(&waitPollInterval, "poll-interval", "1s", "How often to check if session exists")
+	waitCmd.Flags().StringVar(&waitPollInterval, "poll-interval", "1s", "How often to check the condition")
 	waitCmd.Flags().StringVar(&waitTimeout, "timeout", "0s", "Maximum time to wait (0 = no timeout)")
+	waitCmd.Flags().StringVar(&waitPane, "pane", "", "Pane target to watch (e.g. session:window.pane)")
+	waitCmd.Flags().BoolVar(&waitExit, "exit", false, "Wait for the --pane command to exit and exit with its status")
+	waitCmd.Flags().StringVar(&waitMatch, "match", "", "Wait until captured pane output matches this regular expression")
+	waitCmd.Flags().BoolVar(&waitAny, "any", false, "Succeed as soon as any target meets the condition")
+	waitCmd.Flags().BoolVar(&waitAll, "all", false, "Succeed once every target meets the condition (default)")
+	waitCmd.Flags().BoolVar(&waitJSON, "json", false, "Output the result as JSON")
 }
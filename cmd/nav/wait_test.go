@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeWaitCheck finishes each target after the given number of polls; a
+// target mapped to -1 never finishes and one in failing returns an error.
+func fakeWaitCheck(pollsUntilDone map[string]int, failing map[string]bool) waitCheck {
+	polls := map[string]int{}
+	return func(_ context.Context, target string) (waitTargetResult, error) {
+		if failing[target] {
+			return waitTargetResult{Target: target}, errors.New("session not found")
+		}
+		polls[target]++
+		n := pollsUntilDone[target]
+		return waitTargetResult{Target: target, Done: n >= 0 && polls[target] >= n}, nil
+	}
+}
+
+func TestPollWait(t *testing.T) {
+	tests := []struct {
+		name          string
+		targets       []string
+		polls         map[string]int
+		failing       map[string]bool
+		matchAny      bool
+		wantSatisfied bool
+		wantTimedOut  bool
+		wantDone      []bool
+		wantError     string
+	}{
+		{
+			name:          "immediate success",
+			targets:       []string{"api"},
+			polls:         map[string]int{"api": 1},
+			wantSatisfied: true,
+			wantDone:      []bool{true},
+		},
+		{
+			name:          "all waits for every target",
+			targets:       []string{"api", "web"},
+			polls:         map[string]int{"api": 1, "web": 3},
+			wantSatisfied: true,
+			wantDone:      []bool{true, true},
+		},
+		{
+			name:          "any stops at the first target",
+			targets:       []string{"api", "web"},
+			polls:         map[string]int{"api": -1, "web": 2},
+			matchAny:      true,
+			wantSatisfied: true,
+			wantDone:      []bool{false, true},
+		},
+		{
+			name:         "all times out while a target is unfinished",
+			targets:      []string{"api", "web"},
+			polls:        map[string]int{"api": 1, "web": -1},
+			wantTimedOut: true,
+			wantDone:     []bool{true, false},
+		},
+		{
+			name:      "a target error ends the wait",
+			targets:   []string{"api", "web"},
+			polls:     map[string]int{"api": -1},
+			failing:   map[string]bool{"web": true},
+			matchAny:  true,
+			wantDone:  []bool{false, false},
+			wantError: "session not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			got := pollWait(ctx, tt.targets, fakeWaitCheck(tt.polls, tt.failing), tt.matchAny, time.Millisecond)
+			if got.Satisfied != tt.wantSatisfied || got.TimedOut != tt.wantTimedOut {
+				t.Errorf("Satisfied = %v, TimedOut = %v, want %v, %v", got.Satisfied, got.TimedOut, tt.wantSatisfied, tt.wantTimedOut)
+			}
+			if len(got.Targets) != len(tt.targets) {
+				t.Fatalf("got %d target results, want %d", len(got.Targets), len(tt.targets))
+			}
+			var gotError string
+			for i, r := range got.Targets {
+				if r.Target != tt.targets[i] || r.Done != tt.wantDone[i] {
+					t.Errorf("target %d = %+v, want %s done=%v", i, r, tt.targets[i], tt.wantDone[i])
+				}
+				if r.Error != "" {
+					gotError = r.Error
+				}
+			}
+			if gotError != tt.wantError {
+				t.Errorf("target error = %q, want %q", gotError, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestReportWait(t *testing.T) {
+	zero := 0
+	tests := []struct {
+		name    string
+		result  waitResult
+		wantErr string
+	}{
+		{
+			name:   "satisfied",
+			result: waitResult{Condition: "close", Satisfied: true, Targets: []waitTargetResult{{Target: "api", Done: true}}},
+		},
+		{
+			name:   "pane exited cleanly",
+			result: waitResult{Condition: "exit", Satisfied: true, Targets: []waitTargetResult{{Target: "api:0.1", Done: true, ExitStatus: &zero}}},
+		},
+		{
+			name:    "target error",
+			result:  waitResult{Condition: "close", Targets: []waitTargetResult{{Target: "api", Error: "session not found"}}},
+			wantErr: "error waiting for api: session not found",
+		},
+		{
+			name:    "timeout",
+			result:  waitResult{Condition: "match", TimedOut: true, Targets: []waitTargetResult{{Target: "api"}}},
+			wantErr: "timeout waiting for match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reportWait(tt.result)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("reportWait() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("reportWait() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/grovetools/core/pkg/models"
 
 	"github.com/grovetools/nav/pkg/api"
+	navbindings "github.com/grovetools/nav/pkg/bindings"
 )
 
 // Type aliases for the extracted nav binding types now living in core/pkg/models.
@@ -36,14 +37,20 @@ type ResolvedFeatures = api.Features
 // This struct only contains static configuration specific to nav itself.
 // Project discovery is handled by grove-core's DiscoveryService.
 type TmuxConfig struct {
-	Mode               string              `yaml:"mode,omitempty" toml:"mode,omitempty" jsonschema:"description=Mode preset: 'bare' (pure sessionizer)\\, 'advanced' (groups + worktrees)\\, 'grove' (all features). Defaults to 'grove'.,enum=bare,enum=advanced,enum=grove" jsonschema_extras:"x-layer=global,x-priority=68"`
-	Features           *NavFeatures        `yaml:"features,omitempty" toml:"features,omitempty" jsonschema:"description=Granular feature overrides that take precedence over mode preset"`
-	Prefix             string              `yaml:"prefix,omitempty" toml:"prefix,omitempty" jsonschema:"description=Prefix key for nav bindings. Options: '<prefix>' (default)\\, '<prefix> X' (sub-table under prefix)\\, 'C-g' (dedicated root key)\\, or '' (direct root with modifiers)." jsonschema_extras:"x-layer=global,x-priority=69"`
-	DefaultIcon        string              `yaml:"default_icon,omitempty" toml:"default_icon,omitempty" jsonschema:"description=Icon for the default group. Defaults to home icon."`
-	AvailableKeys      []string            `yaml:"available_keys" toml:"available_keys" jsonschema:"description=Keys available for tmux pane shortcuts. Defaults to a-z excluding 'q' (reserved as nav's table escape key) when unset." jsonschema_extras:"x-layer=global,x-priority=70,x-important=true"`
-	ShowChildProcesses bool                `yaml:"show_child_processes,omitempty" toml:"show_child_processes" jsonschema:"description=Show child processes in pane list" jsonschema_extras:"x-layer=global,x-priority=71"`
-	Groups             map[string]GroupRef `yaml:"groups,omitempty" toml:"groups,omitempty" jsonschema:"description=Workspace groups for multiple key prefixes"`
-	ConfirmKeyUpdates  *bool               `yaml:"confirm_key_updates,omitempty" toml:"confirm_key_updates,omitempty" jsonschema:"description=Show confirmation prompts for bulk key update operations (L/U). Defaults to true." jsonschema_extras:"x-layer=global,x-priority=72"`
+	Mode                string                    `yaml:"mode,omitempty" toml:"mode,omitempty" jsonschema:"description=Mode preset: 'bare' (pure sessionizer)\\, 'advanced' (groups + worktrees)\\, 'grove' (all features). Defaults to 'grove'.,enum=bare,enum=advanced,enum=grove" jsonschema_extras:"x-layer=global,x-priority=68"`
+	Features            *NavFeatures              `yaml:"features,omitempty" toml:"features,omitempty" jsonschema:"description=Granular feature overrides that take precedence over mode preset"`
+	Prefix              string                    `yaml:"prefix,omitempty" toml:"prefix,omitempty" jsonschema:"description=Prefix key for nav bindings. Options: '<prefix>' (default)\\, '<prefix> X' (sub-table under prefix)\\, 'C-g' (dedicated root key)\\, or '' (direct root with modifiers)." jsonschema_extras:"x-layer=global,x-priority=69"`
+	DefaultIcon         string                    `yaml:"default_icon,omitempty" toml:"default_icon,omitempty" jsonschema:"description=Icon for the default group. Defaults to home icon."`
+	AvailableKeys       []string                  `yaml:"available_keys" toml:"available_keys" jsonschema:"description=Keys available for tmux pane shortcuts. Defaults to a-z excluding 'q' (reserved as nav's table escape key) when unset. Projects can also be mapped to chords of these keys (e.g. 'gw')." jsonschema_extras:"x-layer=global,x-priority=70,x-important=true"`
+	ShowChildProcesses  bool                      `yaml:"show_child_processes,omitempty" toml:"show_child_processes" jsonschema:"description=Show child processes in pane list" jsonschema_extras:"x-layer=global,x-priority=71"`
+	Groups              map[string]GroupRef       `yaml:"groups,omitempty" toml:"groups,omitempty" jsonschema:"description=Workspace groups for multiple key prefixes"`
+	ConfirmKeyUpdates   *bool                     `yaml:"confirm_key_updates,omitempty" toml:"confirm_key_updates,omitempty" jsonschema:"description=Show confirmation prompts for bulk key update operations (L/U). Defaults to true." jsonschema_extras:"x-layer=global,x-priority=72"`
+	Layouts             map[string]LayoutConfig   `yaml:"layouts,omitempty" toml:"layouts,omitempty" jsonschema:"description=Named session layouts (windows\\, pane splits\\, and startup commands) applied when a session is created"`
+	DefaultLayout       string                    `yaml:"default_layout,omitempty" toml:"default_layout,omitempty" jsonschema:"description=Layout applied to new sessions that have no group or mapping layout"`
+	Mappings            map[string]SessionOptions `yaml:"mappings,omitempty" toml:"mappings,omitempty" jsonschema:"description=Per-project options for the default group's session mappings\\, keyed by project path (~ allowed). The options follow the project when its key changes."`
+	Hooks               *HooksConfig              `yaml:"hooks,omitempty" toml:"hooks,omitempty" jsonschema:"description=Commands run when any nav session is created\\, switched to\\, or killed"`
+	SessionNameTemplate string                    `yaml:"session_name_template,omitempty" toml:"session_name_template,omitempty" jsonschema:"description=Template for session names using {name}\\, {repo}\\, {worktree}\\, {ecosystem} and {identifier} (e.g. '{ecosystem}/{repo}@{worktree}'). Defaults to the project identifier."`
+	HistoryPrefix       string                    `yaml:"history_prefix,omitempty" toml:"history_prefix,omitempty" jsonschema:"description=Prefix for history-slot hotkeys: keys 1-9 under it jump to the 1st-9th most recently used project (e.g. '<prefix>' binds <prefix> 1..9 and replaces tmux's window selection; '<prefix> h' uses a sub-table). Disabled when empty."`
 }
 
 // DefaultAvailableKeys returns the built-in key set used when the user's
@@ -74,6 +81,54 @@ type GroupRef struct {
 	Sessions map[string]TmuxSessionConfig `yaml:"sessions,omitempty" toml:"sessions,omitempty"`
 	Active   *bool                        `yaml:"active,omitempty" toml:"active,omitempty"`
 	Order    int                          `yaml:"order,omitempty" toml:"order,omitempty"` // Display order in group list
+	Layout   string                       `yaml:"layout,omitempty" toml:"layout,omitempty"`
+	Mappings map[string]SessionOptions    `yaml:"mappings,omitempty" toml:"mappings,omitempty"` // Per-project options, keyed by project path
+	Env      map[string]string            `yaml:"env,omitempty" toml:"env,omitempty"`           // Default environment for every session in the group
+	EnvFile  string                       `yaml:"env_file,omitempty" toml:"env_file,omitempty"` // .env file loaded before Env
+	Hooks    *HooksConfig                 `yaml:"hooks,omitempty" toml:"hooks,omitempty"`       // Lifecycle hooks for sessions in the group
+}
+
+// SessionOptions holds nav-specific settings for a single key mapping. The
+// mapping itself (key → path) is a core NavSessionConfig persisted by the
+// daemon; options live in static config keyed by the mapped project's
+// path, so moving, unmapping or pruning a key never leaves them behind for
+// another project.
+type SessionOptions struct {
+	Layout  string            `yaml:"layout,omitempty" toml:"layout,omitempty" jsonschema:"description=Name of the layout in nav.layouts to build when this session is created"`
+	Env     map[string]string `yaml:"env,omitempty" toml:"env,omitempty" jsonschema:"description=Environment variables set when this session is created. Overrides group env."`
+	EnvFile string            `yaml:"env_file,omitempty" toml:"env_file,omitempty" jsonschema:"description=.env file loaded before env. Relative paths resolve against the project root."`
+	Hooks   *HooksConfig      `yaml:"hooks,omitempty" toml:"hooks,omitempty" jsonschema:"description=Lifecycle hooks for this session. Run after global and group hooks."`
+	Action  string            `yaml:"action,omitempty" toml:"action,omitempty" jsonschema:"description=What the hotkey does: sessionize (default)\\, window\\, popup\\, tui or nav,enum=sessionize,enum=window,enum=popup,enum=tui,enum=nav"`
+	Window  string            `yaml:"window,omitempty" toml:"window,omitempty" jsonschema:"description=Window name or index selected in the project session (action window). Created in the project directory if missing."`
+	Command string            `yaml:"command,omitempty" toml:"command,omitempty" jsonschema:"description=Command run in a popup in the project directory (action popup)\\, or nav arguments such as 'history last' (action nav)"`
+	Tab     string            `yaml:"tab,omitempty" toml:"tab,omitempty" jsonschema:"description=nav TUI tab to open (action tui): sessionize (default)\\, keys\\, history\\, windows or groups"`
+	Filter  string            `yaml:"filter,omitempty" toml:"filter,omitempty" jsonschema:"description=Initial sessionizer filter (action tui)"`
+	Repeat  *bool             `yaml:"repeat,omitempty" toml:"repeat,omitempty" jsonschema:"description=Bind the key as repeatable (tmux -r). Defaults to true for sessionize and false for other actions."`
+	Windows map[string]string `yaml:"windows,omitempty" toml:"windows,omitempty" jsonschema:"description=Window hotkeys: a key typed right after this mapping's hotkey selects the named window in the project session (e.g. t: tests for <prefix> a t). A missing window is created from the layout window of that name\\, or in the project directory."`
+}
+
+// BindingAction converts the mapping's action settings for the binding
+// generators.
+func (o SessionOptions) BindingAction() navbindings.Action {
+	return navbindings.Action{
+		Kind:    navbindings.ActionKind(o.Action),
+		Window:  o.Window,
+		Command: o.Command,
+		Tab:     o.Tab,
+		Filter:  o.Filter,
+		Repeat:  o.Repeat,
+		Windows: o.Windows,
+	}
+}
+
+// HooksConfig holds shell commands run on session lifecycle events. Hooks
+// are configured globally, per group, and per mapping; every level that
+// defines a command for an event runs, global first.
+type HooksConfig struct {
+	OnCreate string `yaml:"on_create,omitempty" toml:"on_create,omitempty" jsonschema:"description=Command run after a session is created"`
+	OnSwitch string `yaml:"on_switch,omitempty" toml:"on_switch,omitempty" jsonschema:"description=Command run after switching or attaching to a session"`
+	OnKill   string `yaml:"on_kill,omitempty" toml:"on_kill,omitempty" jsonschema:"description=Command run after a session is killed"`
+	Timeout  string `yaml:"timeout,omitempty" toml:"timeout,omitempty" jsonschema:"description=Maximum run time for each hook command (Go duration\\, e.g. 30s). Defaults to 10s."`
 }
 
 // Note: GroupState, TmuxSessionsFile, and TmuxSessionConfig are now type aliases
@@ -0,0 +1,325 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/paths"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+)
+
+// DoctorStatus is the outcome of one doctor check.
+type DoctorStatus string
+
+const (
+	DoctorPass DoctorStatus = "pass"
+	DoctorWarn DoctorStatus = "warn"
+	DoctorFail DoctorStatus = "fail"
+)
+
+// DoctorCheck is the result of one `nav doctor` check. Details lists the
+// offending items, e.g. missing bindings or paths.
+type DoctorCheck struct {
+	Name    string       `json:"name"`
+	Status  DoctorStatus `json:"status"`
+	Message string       `json:"message"`
+	Details []string     `json:"details,omitempty"`
+}
+
+// maxDoctorDetails caps how many offending items a check lists.
+const maxDoctorDetails = 10
+
+// Doctor checks that the running tmux server is set up for nav's hotkeys:
+// the generated bindings are loaded and not shadowed, the history hook is
+// installed, mapped paths exist, the daemon is reachable, nav talks to the
+// same tmux server as the current client, and the grove bin directory holds
+// the nav being run.
+func (m *Manager) Doctor() []DoctorCheck {
+	opts := navbindings.Options{
+		BinDir:        paths.BinDir(),
+		CacheDir:      paths.CacheDir(),
+		SkipMissing:   true,
+		HistoryPrefix: m.GetHistoryPrefix(),
+	}
+	groups := m.GroupBindings()
+
+	var checks []DoctorCheck
+	expected, err := navbindings.ExpectedTmuxKeys(groups, opts)
+	if err != nil {
+		checks = append(checks, DoctorCheck{
+			Name:    "bindings",
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("bindings cannot be generated: %v", err),
+		})
+	} else if out, err := tmuxCommand("list-keys").Output(); err != nil {
+		checks = append(checks, DoctorCheck{
+			Name:    "bindings",
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("tmux server not reachable: %v", err),
+		})
+	} else {
+		live := navbindings.ParseTmuxKeys(string(out))
+		checks = append(checks,
+			checkBindingsLoaded(expected, live, navbindings.TmuxConfPath(opts)),
+			checkPrefixShadowing(expected, live),
+		)
+	}
+
+	hooks, err := tmuxCommand("show-hooks", "-g").Output()
+	if err != nil {
+		checks = append(checks, DoctorCheck{
+			Name:    "history-hook",
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("cannot read tmux hooks: %v", err),
+		})
+	} else {
+		checks = append(checks, checkHistoryHook(string(hooks)))
+	}
+
+	checks = append(checks,
+		checkMappedPaths(groups),
+		checkDaemon(daemon.New().IsRunning()),
+		checkTmuxSocket(os.Getenv(mux.EnvGroveTmuxSocket), os.Getenv("TMUX")),
+	)
+
+	executable, err := os.Executable()
+	if err != nil {
+		checks = append(checks, DoctorCheck{
+			Name:    "nav-binary",
+			Status:  DoctorWarn,
+			Message: fmt.Sprintf("cannot resolve the running nav binary: %v", err),
+		})
+	} else {
+		checks = append(checks, checkNavBinary(executable, opts.BinDir))
+	}
+	return checks
+}
+
+// navOwned reports whether a binding runs nav or enters one of nav's key
+// tables, as opposed to tmux defaults or the user's own bindings.
+func navOwned(k navbindings.TmuxKey) bool {
+	return strings.Contains(k.Command, "nav ") || strings.Contains(k.Command, "-T nav-")
+}
+
+// isSharedTable reports whether table is one nav shares with the user.
+func isSharedTable(table string) bool {
+	return table == "root" || table == "prefix"
+}
+
+// liveKeyMap indexes live bindings by table and key.
+func liveKeyMap(live []navbindings.TmuxKey) map[string]navbindings.TmuxKey {
+	byKey := make(map[string]navbindings.TmuxKey, len(live))
+	for _, k := range live {
+		byKey[k.Table+" "+k.Key] = k
+	}
+	return byKey
+}
+
+// checkBindingsLoaded compares the bindings nav would generate with those
+// in the running server. A binding is loaded when the server binds the same
+// key in the same table to a nav command; bindings shadowed in the root or
+// prefix table are reported by checkPrefixShadowing instead.
+func checkBindingsLoaded(expected, live []navbindings.TmuxKey, confPath string) DoctorCheck {
+	check := DoctorCheck{Name: "bindings"}
+	byKey := liveKeyMap(live)
+
+	total := 0
+	var missing []string
+	for _, k := range expected {
+		if !navOwned(k) {
+			continue
+		}
+		total++
+		got, ok := byKey[k.Table+" "+k.Key]
+		if ok && (navOwned(got) || isSharedTable(k.Table)) {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s %s", k.Table, k.Key))
+	}
+
+	switch {
+	case total == 0:
+		check.Status = DoctorWarn
+		check.Message = "no nav bindings are configured"
+	case len(missing) == total:
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("generated bindings are not loaded; run 'nav tmux install' or add 'source-file %s' to your tmux.conf", confPath)
+	case len(missing) > 0:
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%d of %d bindings are not loaded; run 'nav key regenerate' and 'tmux source-file %s'", len(missing), total, confPath)
+		check.Details = truncateDetails(missing)
+	default:
+		check.Status = DoctorPass
+		check.Message = fmt.Sprintf("%d bindings loaded", total)
+	}
+	return check
+}
+
+// checkPrefixShadowing reports nav entry points in the root or prefix
+// table that the server binds to something else, typically a user binding
+// sourced after nav's file.
+func checkPrefixShadowing(expected, live []navbindings.TmuxKey) DoctorCheck {
+	check := DoctorCheck{Name: "prefix-shadowing", Status: DoctorPass, Message: "no nav prefix is shadowed"}
+	byKey := liveKeyMap(live)
+	var shadowed []string
+	for _, k := range expected {
+		if !navOwned(k) || !isSharedTable(k.Table) {
+			continue
+		}
+		if got, ok := byKey[k.Table+" "+k.Key]; ok && !navOwned(got) {
+			shadowed = append(shadowed, fmt.Sprintf("%s %s: %s", k.Table, k.Key, got.Command))
+		}
+	}
+	if len(shadowed) > 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%d nav key(s) are rebound after nav's bindings are sourced", len(shadowed))
+		check.Details = truncateDetails(shadowed)
+	}
+	return check
+}
+
+// checkHistoryHook looks for nav's hooks in the output of
+// `tmux show-hooks -g`. Without the client-session-changed hook switches
+// are not recorded at all; the others only record session end times.
+func checkHistoryHook(hooks string) DoctorCheck {
+	installed := make(map[string]bool)
+	for _, line := range strings.Split(hooks, "\n") {
+		if !strings.Contains(line, "nav record-session") {
+			continue
+		}
+		event, _, _ := strings.Cut(line, " ")
+		event, _, _ = strings.Cut(event, "[")
+		installed[event] = true
+	}
+	if !installed["client-session-changed"] {
+		return DoctorCheck{
+			Name:    "history-hook",
+			Status:  DoctorFail,
+			Message: "client-session-changed hook is missing; session switches are not recorded in history",
+		}
+	}
+	var missing []string
+	for _, event := range navbindings.TmuxHookEvents() {
+		if !installed[event] {
+			missing = append(missing, event)
+		}
+	}
+	if len(missing) > 0 {
+		return DoctorCheck{
+			Name:    "history-hook",
+			Status:  DoctorWarn,
+			Message: "session end times are not recorded; run 'nav key regenerate' and reload tmux",
+			Details: missing,
+		}
+	}
+	return DoctorCheck{Name: "history-hook", Status: DoctorPass, Message: "history hooks installed"}
+}
+
+// checkMappedPaths reports mapped keys whose path no longer exists.
+func checkMappedPaths(groups []navbindings.GroupBinding) DoctorCheck {
+	check := DoctorCheck{Name: "mapped-paths"}
+	total := 0
+	var missing []string
+	for _, g := range groups {
+		keys := make([]string, 0, len(g.Sessions))
+		for key, sess := range g.Sessions {
+			if sess.Path != "" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			total++
+			path := g.Sessions[key].Path
+			if _, err := os.Stat(expandPath(path)); err != nil {
+				missing = append(missing, fmt.Sprintf("%s %s: %s", g.Name, key, path))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%d of %d mapped paths do not exist", len(missing), total)
+		check.Details = truncateDetails(missing)
+		return check
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("all %d mapped paths exist", total)
+	return check
+}
+
+// checkDaemon reports whether the grove daemon answers. nav falls back to
+// reading state directly when it does not, so this is only a warning.
+func checkDaemon(running bool) DoctorCheck {
+	if running {
+		return DoctorCheck{Name: "daemon", Status: DoctorPass, Message: "grove daemon is reachable"}
+	}
+	return DoctorCheck{
+		Name:    "daemon",
+		Status:  DoctorWarn,
+		Message: "grove daemon is not reachable; nav falls back to local state",
+	}
+}
+
+// checkTmuxSocket compares the server nav talks to (GROVE_TMUX_SOCKET, or
+// tmux's default socket) with the server of the current client ($TMUX).
+func checkTmuxSocket(groveSocket, tmuxEnv string) DoctorCheck {
+	check := DoctorCheck{Name: "tmux-socket", Status: DoctorPass}
+	want := groveSocket
+	if want == "" {
+		want = "default"
+	}
+	if tmuxEnv == "" {
+		check.Message = fmt.Sprintf("not inside tmux; nav uses socket %q", want)
+		return check
+	}
+	socketPath, _, _ := strings.Cut(tmuxEnv, ",")
+	if got := filepath.Base(socketPath); got != want {
+		check.Status = DoctorFail
+		if groveSocket == "" {
+			check.Message = fmt.Sprintf("this client is on socket %q but nav uses the default server; set GROVE_TMUX_SOCKET=%s", got, got)
+		} else {
+			check.Message = fmt.Sprintf("GROVE_TMUX_SOCKET is %q but this client is on socket %q", groveSocket, got)
+		}
+		return check
+	}
+	check.Message = fmt.Sprintf("nav and this client share socket %q", want)
+	return check
+}
+
+// checkNavBinary reports whether the nav in binDir, which the generated
+// bindings run, is the binary currently executing.
+func checkNavBinary(executable, binDir string) DoctorCheck {
+	check := DoctorCheck{Name: "nav-binary"}
+	binNav := filepath.Join(binDir, "nav")
+	resolvedBin, err := filepath.EvalSymlinks(binNav)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%s does not exist; generated bindings cannot run nav", binNav)
+		return check
+	}
+	if resolvedExe, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolvedExe
+	}
+	if resolvedBin != executable {
+		check.Status = DoctorWarn
+		check.Message = fmt.Sprintf("running %s, but bindings run %s", executable, resolvedBin)
+		return check
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("bindings run this nav (%s)", binNav)
+	return check
+}
+
+// truncateDetails caps a list of offending items at maxDoctorDetails.
+func truncateDetails(items []string) []string {
+	if len(items) <= maxDoctorDetails {
+		return items
+	}
+	return append(items[:maxDoctorDetails:maxDoctorDetails], fmt.Sprintf("... and %d more", len(items)-maxDoctorDetails))
+}
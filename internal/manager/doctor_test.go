@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+)
+
+func TestCheckBindings(t *testing.T) {
+	expected := []navbindings.TmuxKey{
+		{Table: "prefix", Key: "g", Command: "switch-client -T nav-workspaces"},
+		{Table: "nav-workspaces", Key: "q", Command: "switch-client -T root"},
+		{Table: "nav-workspaces", Key: "a", Command: `run-shell "nav sessionize '/src/a'"`},
+		{Table: "nav-workspaces", Key: "b", Command: `run-shell "nav sessionize '/src/b'"`},
+	}
+
+	tests := []struct {
+		name          string
+		live          []navbindings.TmuxKey
+		wantLoaded    DoctorStatus
+		wantShadowing DoctorStatus
+	}{
+		{
+			name:          "loaded",
+			live:          expected,
+			wantLoaded:    DoctorPass,
+			wantShadowing: DoctorPass,
+		},
+		{
+			name:          "not sourced",
+			live:          []navbindings.TmuxKey{{Table: "prefix", Key: "c", Command: "new-window"}},
+			wantLoaded:    DoctorFail,
+			wantShadowing: DoctorPass,
+		},
+		{
+			name:          "stale",
+			live:          expected[:3],
+			wantLoaded:    DoctorFail,
+			wantShadowing: DoctorPass,
+		},
+		{
+			name: "shadowed prefix",
+			live: append([]navbindings.TmuxKey{
+				{Table: "prefix", Key: "g", Command: "display-popup lazygit"},
+			}, expected[1:]...),
+			wantLoaded:    DoctorPass,
+			wantShadowing: DoctorFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkBindingsLoaded(expected, tt.live, "/cache/nav/generated-bindings.conf"); got.Status != tt.wantLoaded {
+				t.Errorf("checkBindingsLoaded() = %+v, want %s", got, tt.wantLoaded)
+			}
+			if got := checkPrefixShadowing(expected, tt.live); got.Status != tt.wantShadowing {
+				t.Errorf("checkPrefixShadowing() = %+v, want %s", got, tt.wantShadowing)
+			}
+		})
+	}
+}
+
+func TestCheckBindingsPunctuationChord(t *testing.T) {
+	groups := []navbindings.GroupBinding{{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"g .": {Path: "/src/a"},
+			"g ;": {Path: "/src/b"},
+			"g '": {Path: "/src/c"},
+		},
+	}}
+	expected, err := navbindings.ExpectedTmuxKeys(groups, navbindings.Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Render the bindings the way `tmux list-keys` prints them, with
+	// the chord steps backslash-escaped rather than quoted.
+	listKeysForm := map[string]string{"/src/a": `\.`, "/src/b": `\;`, "/src/c": `\'`}
+	var listKeys strings.Builder
+	for _, k := range expected {
+		key := k.Key
+		for path, form := range listKeysForm {
+			if strings.Contains(k.Command, "'"+path+"'") {
+				key = form
+			}
+		}
+		fmt.Fprintf(&listKeys, "bind-key -T %s %s %s\n", k.Table, key, k.Command)
+	}
+	live := navbindings.ParseTmuxKeys(listKeys.String())
+	if len(live) != len(expected) {
+		t.Fatalf("parsed %d live bindings, want %d", len(live), len(expected))
+	}
+
+	if got := checkBindingsLoaded(expected, live, "/cache/nav/generated-bindings.conf"); got.Status != DoctorPass {
+		t.Errorf("checkBindingsLoaded() = %+v, want %s", got, DoctorPass)
+	}
+}
+
+func TestCheckHistoryHook(t *testing.T) {
+	switched := "client-session-changed[970] run-shell -b \"HOME=$HOME PATH=$PATH:/bin nav record-session\"\n"
+	ended := "session-closed[970] run-shell -b \"nav record-session --event closed --session #{q:hook_session_name}\"\n" +
+		"client-detached[970] run-shell -b \"nav record-session --event detached --session #{q:session_name}\"\n"
+	if got := checkHistoryHook("client-session-changed[0] run-shell my-plugin\n" + switched + ended); got.Status != DoctorPass {
+		t.Errorf("installed hooks reported %+v", got)
+	}
+	if got := checkHistoryHook(switched); got.Status != DoctorWarn || len(got.Details) != 2 {
+		t.Errorf("missing end hooks reported %+v", got)
+	}
+	if got := checkHistoryHook("pane-exited[0] run-shell true\n"); got.Status != DoctorFail {
+		t.Errorf("missing hook reported %+v", got)
+	}
+}
+
+func TestCheckTmuxSocket(t *testing.T) {
+	tests := []struct {
+		name        string
+		groveSocket string
+		tmuxEnv     string
+		want        DoctorStatus
+	}{
+		{"outside tmux", "", "", DoctorPass},
+		{"default server", "", "/tmp/tmux-501/default,123,0", DoctorPass},
+		{"matching socket", "grove", "/tmp/tmux-501/grove,123,0", DoctorPass},
+		{"unset but client on named socket", "", "/tmp/tmux-501/grove,123,0", DoctorFail},
+		{"mismatch", "grove", "/tmp/tmux-501/default,123,0", DoctorFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkTmuxSocket(tt.groveSocket, tt.tmuxEnv); got.Status != tt.want {
+				t.Errorf("checkTmuxSocket() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckNavBinary(t *testing.T) {
+	binDir := t.TempDir()
+	other := filepath.Join(t.TempDir(), "nav")
+
+	if got := checkNavBinary(other, binDir); got.Status != DoctorFail {
+		t.Errorf("missing bin nav reported %+v", got)
+	}
+
+	binNav := filepath.Join(binDir, "nav")
+	if err := os.WriteFile(binNav, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := checkNavBinary(binNav, binDir); got.Status != DoctorPass {
+		t.Errorf("same binary reported %+v", got)
+	}
+	if got := checkNavBinary(other, binDir); got.Status != DoctorWarn {
+		t.Errorf("different binary reported %+v", got)
+	}
+}
+
+func TestCheckMappedPaths(t *testing.T) {
+	dir := t.TempDir()
+	groups := []navbindings.GroupBinding{{
+		Name: "default",
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: dir},
+			"b": {Path: filepath.Join(dir, "gone")},
+			"c": {},
+		},
+	}}
+	got := checkMappedPaths(groups)
+	if got.Status != DoctorFail || len(got.Details) != 1 {
+		t.Errorf("checkMappedPaths() = %+v, want one missing path", got)
+	}
+}
@@ -0,0 +1,122 @@
+package manager
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ResolveEnv returns the environment applied to a new session for path.
+// Later sources override earlier ones: the group's env_file, the group's
+// env, the mapping's env_file, then the mapping's env. Relative env_file
+// paths resolve against the project root. Paths that are not mapped get
+// no extra environment.
+func (m *Manager) ResolveEnv(path string) (map[string]string, error) {
+	if m.tmuxConfig == nil {
+		return nil, nil
+	}
+	root := expandPath(path)
+	group, key, ok := m.FindMappingForPath(root)
+	if !ok {
+		return nil, nil
+	}
+
+	env := make(map[string]string)
+	if group != "default" {
+		if ref, exists := m.tmuxConfig.Groups[group]; exists {
+			if err := mergeEnv(env, root, ref.EnvFile, ref.Env); err != nil {
+				return nil, fmt.Errorf("group %s: %w", group, err)
+			}
+		}
+	}
+	if opts, exists := m.GetMappingOptions(group, root); exists {
+		if err := mergeEnv(env, root, opts.EnvFile, opts.Env); err != nil {
+			return nil, fmt.Errorf("mapping %s: %w", key, err)
+		}
+	}
+	if len(env) == 0 {
+		return nil, nil
+	}
+	return env, nil
+}
+
+// mergeEnv loads envFile (if set) and then vars into env.
+func mergeEnv(env map[string]string, root, envFile string, vars map[string]string) error {
+	if envFile != "" {
+		fileVars, err := ParseEnvFile(resolveLayoutDir(root, envFile))
+		if err != nil {
+			return err
+		}
+		for k, v := range fileVars {
+			env[k] = v
+		}
+	}
+	if err := validateEnvNames(vars); err != nil {
+		return err
+	}
+	for k, v := range vars {
+		env[k] = v
+	}
+	return nil
+}
+
+// ParseEnvFile reads a dotenv-style file: KEY=VALUE lines, optional
+// "export " prefixes, # comments, and single- or double-quoted values.
+// Variables are not expanded.
+func ParseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer f.Close()
+
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if idx := strings.Index(value, " #"); idx != -1 {
+			value = strings.TrimSpace(value[:idx])
+		}
+		if err := validateEnvName(key); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	return env, nil
+}
+
+// validateEnvNames checks every key of env with validateEnvName.
+func validateEnvNames(env map[string]string) error {
+	for k := range env {
+		if err := validateEnvName(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateEnvName rejects names tmux's -e KEY=VALUE form cannot carry.
+func validateEnvName(name string) error {
+	if name == "" || strings.ContainsAny(name, "= \t") {
+		return fmt.Errorf("invalid environment variable name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# comment
+AWS_PROFILE=dev
+export GOFLAGS=-race
+QUOTED="hello world"
+SINGLE='a=b'
+TRAILING=value # note
+
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseEnvFile(path)
+	if err != nil {
+		t.Fatalf("ParseEnvFile() error = %v", err)
+	}
+	want := map[string]string{
+		"AWS_PROFILE": "dev",
+		"GOFLAGS":     "-race",
+		"QUOTED":      "hello world",
+		"SINGLE":      "a=b",
+		"TRAILING":    "value",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseEnvFile() = %v, want %v", got, want)
+	}
+}
+
+func TestParseEnvFileRejectsMalformedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("NOT_AN_ASSIGNMENT\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseEnvFile(path); err == nil {
+		t.Fatal("expected an error for a line without '='")
+	}
+}
+
+func TestResolveEnvPrecedence(t *testing.T) {
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, ".env"), []byte("A=file\nB=file\nC=file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := TmuxConfig{
+		Groups: map[string]GroupRef{
+			"work": {
+				Prefix:  "<prefix> w",
+				EnvFile: ".env",
+				Env:     map[string]string{"B": "group", "C": "group"},
+				Mappings: map[string]SessionOptions{
+					project: {Env: map[string]string{"C": "mapping"}},
+				},
+			},
+		},
+	}
+	m := &Manager{
+		tmuxConfig:  &cfg,
+		activeGroup: "default",
+		sessionsFile: TmuxSessionsFile{
+			Sessions: map[string]TmuxSessionConfig{},
+			Groups: map[string]GroupState{
+				"work": {Sessions: map[string]TmuxSessionConfig{"a": {Path: project}}},
+			},
+		},
+	}
+	m.SetActiveGroup("default")
+
+	got, err := m.ResolveEnv(project)
+	if err != nil {
+		t.Fatalf("ResolveEnv() error = %v", err)
+	}
+	want := map[string]string{"A": "file", "B": "group", "C": "mapping"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveEnv() = %v, want %v", got, want)
+	}
+
+	if env, err := m.ResolveEnv(t.TempDir()); err != nil || env != nil {
+		t.Errorf("ResolveEnv() for unmapped path = %v, %v; want nil, nil", env, err)
+	}
+}
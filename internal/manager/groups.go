@@ -0,0 +1,139 @@
+package manager
+
+import (
+	"fmt"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+)
+
+// Where a group's mappings are persisted (GroupRef.Persist).
+const (
+	PersistState  = "state"  // nav's state file, managed by the daemon (default)
+	PersistInline = "inline" // the group's sessions table in the nav config
+	PersistFile   = "file"   // a separate file named by persist
+)
+
+// GroupInfo summarizes a group's configuration, as shown by
+// `nav key group list --json` and `nav key group show`.
+type GroupInfo struct {
+	Name        string `json:"name"`
+	Prefix      string `json:"prefix"`
+	Icon        string `json:"icon,omitempty"` // configured icon; GroupIconGlyph renders it
+	Active      bool   `json:"active"`
+	Order       int    `json:"order"`
+	Sessions    int    `json:"sessions"`
+	Persist     string `json:"persist"`
+	PersistFile string `json:"persist_file,omitempty"`
+}
+
+// GroupInfos returns every group, including deactivated ones, in display
+// order.
+func (m *Manager) GroupInfos() []GroupInfo {
+	groups := m.GetAllGroups()
+	infos := make([]GroupInfo, 0, len(groups))
+	for _, g := range groups {
+		info, _ := m.GroupInfo(g)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// GroupInfo returns the summary of the named group.
+func (m *Manager) GroupInfo(name string) (GroupInfo, bool) {
+	info := GroupInfo{
+		Name:     name,
+		Prefix:   m.GetPrefixForGroup(name),
+		Active:   true,
+		Sessions: m.GetGroupSessionCount(name),
+		Persist:  PersistState,
+	}
+	if name == "default" {
+		info.Icon = m.GetDefaultIcon()
+		return info, true
+	}
+	ref, ok := m.GetGroupConfig(name)
+	if !ok {
+		return GroupInfo{}, false
+	}
+	info.Icon = ref.Icon
+	info.Active = ref.Active == nil || *ref.Active
+	info.Order = ref.Order
+	info.Persist, info.PersistFile = persistMode(ref.Persist)
+	return info, true
+}
+
+// persistMode interprets a group's persist setting: unset or false keeps
+// mappings in the state file, true inline in the config, and any other
+// string names a file.
+func persistMode(persist interface{}) (mode, file string) {
+	if persist == nil || persist == false || persist == "false" || persist == "" {
+		return PersistState, ""
+	}
+	if s, ok := persist.(string); ok && s != "true" {
+		return PersistFile, s
+	}
+	return PersistInline, ""
+}
+
+// SetGroupIcon sets the icon of a group. For the default group it sets
+// default_icon.
+func (m *Manager) SetGroupIcon(name, icon string) error {
+	if name == "default" {
+		m.tmuxConfig.DefaultIcon = icon
+		return m.saveStaticConfigFull()
+	}
+	if ref, exists := m.tmuxConfig.Groups[name]; exists {
+		ref.Icon = icon
+		m.tmuxConfig.Groups[name] = ref
+		return m.saveStaticConfigFull()
+	}
+	return fmt.Errorf("group not found")
+}
+
+// MoveGroup moves a group to position (1-based) in the display order, after
+// the default group, which always comes first. Every other group is
+// renumbered so orders stay contiguous.
+func (m *Manager) MoveGroup(name string, position int) error {
+	if name == "default" {
+		return fmt.Errorf("the default group is always first")
+	}
+	if _, exists := m.tmuxConfig.Groups[name]; !exists {
+		return fmt.Errorf("group not found")
+	}
+	order := moveGroup(m.GetAllGroups()[1:], name, position)
+	for i, g := range order {
+		ref := m.tmuxConfig.Groups[g]
+		ref.Order = i
+		m.tmuxConfig.Groups[g] = ref
+	}
+	return m.saveStaticConfigFull()
+}
+
+// moveGroup returns groups with name moved to position (1-based, clamped
+// to the list).
+func moveGroup(groups []string, name string, position int) []string {
+	order := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g != name {
+			order = append(order, g)
+		}
+	}
+	i := min(max(position-1, 0), len(order))
+	order = append(order[:i], append([]string{name}, order[i:]...)...)
+	return order
+}
+
+// ValidateGroupPrefix checks what setting the prefix of group name would
+// do to the active groups' bindings. Only conflicts the new prefix
+// introduces are errors; a deactivated group has no bindings to check.
+func (m *Manager) ValidateGroupPrefix(name, prefix string) *navbindings.Report {
+	file, prevConfigs := m.bindingsFile()
+	groupConfigs := make(map[string]navbindings.GroupConfig, len(prevConfigs))
+	for g, cfg := range prevConfigs {
+		groupConfigs[g] = cfg
+	}
+	if _, active := groupConfigs[name]; active {
+		groupConfigs[name] = navbindings.GroupConfig{Prefix: prefix}
+	}
+	return navbindings.ValidateConfigChange(file, prevConfigs, groupConfigs)
+}
@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPersistMode(t *testing.T) {
+	tests := []struct {
+		persist  interface{}
+		mode     string
+		fileName string
+	}{
+		{nil, PersistState, ""},
+		{false, PersistState, ""},
+		{"false", PersistState, ""},
+		{"", PersistState, ""},
+		{true, PersistInline, ""},
+		{"true", PersistInline, ""},
+		{"work-keys.toml", PersistFile, "work-keys.toml"},
+	}
+	for _, tt := range tests {
+		mode, file := persistMode(tt.persist)
+		if mode != tt.mode || file != tt.fileName {
+			t.Errorf("persistMode(%#v) = %q, %q; want %q, %q", tt.persist, mode, file, tt.mode, tt.fileName)
+		}
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	groups := []string{"a", "b", "c", "d"}
+	tests := []struct {
+		name     string
+		position int
+		want     []string
+	}{
+		{"c", 1, []string{"c", "a", "b", "d"}},
+		{"a", 3, []string{"b", "c", "a", "d"}},
+		{"b", 99, []string{"a", "c", "d", "b"}},
+		{"d", 0, []string{"d", "a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		if got := moveGroup(groups, tt.name, tt.position); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("moveGroup(%s, %d) = %v, want %v", tt.name, tt.position, got, tt.want)
+		}
+	}
+	if !reflect.DeepEqual(groups, []string{"a", "b", "c", "d"}) {
+		t.Errorf("moveGroup modified its input: %v", groups)
+	}
+}
@@ -0,0 +1,235 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	grovelogging "github.com/grovetools/core/logging"
+	"github.com/grovetools/core/tui/theme"
+)
+
+var ulogHooks = grovelogging.NewUnifiedLogger("nav.hooks")
+
+// HookEvent names a session lifecycle event that can trigger hooks.
+type HookEvent string
+
+const (
+	HookCreate HookEvent = "on_create"
+	HookSwitch HookEvent = "on_switch"
+	HookKill   HookEvent = "on_kill"
+)
+
+// defaultHookTimeout bounds a hook when no timeout is configured.
+const defaultHookTimeout = 10 * time.Second
+
+// hookWaitDelay is how long a killed hook may hold its output open, e.g.
+// through a child that left its process group, before nav stops waiting.
+const hookWaitDelay = time.Second
+
+// command returns the hook command configured for event.
+func (h *HooksConfig) command(event HookEvent) string {
+	if h == nil {
+		return ""
+	}
+	switch event {
+	case HookCreate:
+		return h.OnCreate
+	case HookSwitch:
+		return h.OnSwitch
+	case HookKill:
+		return h.OnKill
+	}
+	return ""
+}
+
+// timeout returns the configured hook timeout, or fallback when unset.
+func (h *HooksConfig) timeout(fallback time.Duration) (time.Duration, error) {
+	if h == nil || h.Timeout == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(h.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hook timeout %q: %w", h.Timeout, err)
+	}
+	return d, nil
+}
+
+// hookLevel is one level (global, group, mapping) of configured hooks.
+type hookLevel struct {
+	name  string
+	hooks *HooksConfig
+}
+
+// HasHooks reports whether any hook would run for event on a session at path.
+func (m *Manager) HasHooks(event HookEvent, path string) bool {
+	levels, _, _ := m.hookLevels(path)
+	for _, l := range levels {
+		if l.hooks.command(event) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hookLevels returns the hook configuration that applies to path, global
+// first, plus the group and key the path is mapped to (if any).
+func (m *Manager) hookLevels(path string) ([]hookLevel, string, string) {
+	if m.tmuxConfig == nil {
+		return nil, "", ""
+	}
+	levels := []hookLevel{{name: "global", hooks: m.tmuxConfig.Hooks}}
+	if path == "" {
+		return levels, "", ""
+	}
+	group, key, ok := m.FindMappingForPath(path)
+	if !ok {
+		return levels, "", ""
+	}
+	if ref, exists := m.tmuxConfig.Groups[group]; exists && group != "default" {
+		levels = append(levels, hookLevel{name: "group " + group, hooks: ref.Hooks})
+	}
+	if opts, exists := m.GetMappingOptions(group, path); exists {
+		levels = append(levels, hookLevel{name: "mapping " + key, hooks: opts.Hooks})
+	}
+	return levels, group, key
+}
+
+// RunHooks runs every hook configured for event on the session, global
+// first. Each command runs through sh -c in the project directory with
+// NAV_EVENT, NAV_PROJECT_PATH, NAV_SESSION, NAV_GROUP and NAV_KEY set, and
+// is killed after its timeout. A failing hook does not stop later levels;
+// all failures are returned together.
+func (m *Manager) RunHooks(ctx context.Context, event HookEvent, sessionName, path string) error {
+	return m.resolveHooks(event, sessionName, path).run(ctx)
+}
+
+// RunHooksReportingErrors runs hooks for a session operation that has
+// already happened: a hook failure is logged but never fails the
+// operation. When path is empty it is looked up from the running session.
+// quiet keeps failures out of the terminal, for callers inside a TUI.
+func (m *Manager) RunHooksReportingErrors(ctx context.Context, event HookEvent, sessionName, path string, quiet bool) {
+	m.PrepareHooks(event, sessionName, path, quiet)(ctx)
+}
+
+// PrepareHooks resolves the hooks for event now and returns a function that
+// runs them as RunHooksReportingErrors does. The function touches no
+// Manager state, so it may run on another goroutine while the Manager is
+// in use, e.g. to keep a TUI responsive.
+func (m *Manager) PrepareHooks(event HookEvent, sessionName, path string, quiet bool) func(context.Context) {
+	if path == "" {
+		path = SessionPath(sessionName)
+	}
+	batch := m.resolveHooks(event, sessionName, path)
+	return func(ctx context.Context) {
+		if err := batch.run(ctx); err != nil {
+			entry := ulogHooks.Warn("Session hook failed").
+				Field("event", string(event)).
+				Field("session", sessionName).
+				Field("path", batch.path).
+				Err(err).
+				Pretty(fmt.Sprintf("%s %s hook failed for '%s': %v", theme.IconWarning, event, sessionName, err))
+			if quiet {
+				entry = entry.StructuredOnly()
+			}
+			entry.Emit()
+		}
+	}
+}
+
+// hookBatch is the hook commands resolved for one event on one session.
+type hookBatch struct {
+	event    HookEvent
+	path     string
+	env      []string
+	levels   []hookLevel
+	fallback time.Duration
+}
+
+// resolveHooks looks up the hooks that apply to the session at path.
+func (m *Manager) resolveHooks(event HookEvent, sessionName, path string) hookBatch {
+	path = expandPath(path)
+	levels, group, key := m.hookLevels(path)
+
+	// The global timeout is the default for group and mapping hooks.
+	fallback := defaultHookTimeout
+	if m.tmuxConfig != nil {
+		if d, err := m.tmuxConfig.Hooks.timeout(defaultHookTimeout); err == nil {
+			fallback = d
+		}
+	}
+
+	env := append(os.Environ(),
+		"NAV_EVENT="+string(event),
+		"NAV_PROJECT_PATH="+path,
+		"NAV_SESSION="+sessionName,
+		"NAV_GROUP="+group,
+		"NAV_KEY="+key,
+	)
+	return hookBatch{event: event, path: path, env: env, levels: levels, fallback: fallback}
+}
+
+// run runs the batch's commands in order and joins their failures.
+func (b hookBatch) run(ctx context.Context) error {
+	var errs []error
+	for _, l := range b.levels {
+		command := l.hooks.command(b.event)
+		if command == "" {
+			continue
+		}
+		timeout, err := l.hooks.timeout(b.fallback)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s hook: %w", l.name, b.event, err))
+			continue
+		}
+		if err := runHook(ctx, command, b.path, b.env, timeout); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s hook: %w", l.name, b.event, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook runs a single hook command with a timeout. The hook runs in its
+// own process group so that on timeout everything it started is killed,
+// not only the shell.
+func runHook(ctx context.Context, command, dir string, env []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = env
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		cmd.Dir = dir
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = hookWaitDelay
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// SessionPath returns the root directory tmux reports for a running
+// session, or "" when it cannot be determined.
+func SessionPath(sessionName string) string {
+	path, err := tmuxOutput("display-message", "-p", "-t", "="+sessionName+":", "#{session_path}")
+	if err != nil {
+		return ""
+	}
+	return path
+}
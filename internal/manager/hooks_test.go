@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHooksOrderAndEnvironment(t *testing.T) {
+	project := t.TempDir()
+	out := filepath.Join(t.TempDir(), "hooks.log")
+
+	record := func(level string) string {
+		return "echo " + level + " $NAV_EVENT $NAV_SESSION $NAV_GROUP $NAV_KEY $NAV_PROJECT_PATH >> " + out
+	}
+	cfg := TmuxConfig{
+		Hooks: &HooksConfig{OnCreate: record("global")},
+		Groups: map[string]GroupRef{
+			"work": {
+				Prefix: "<prefix> w",
+				Hooks:  &HooksConfig{OnCreate: record("group")},
+				Mappings: map[string]SessionOptions{
+					project: {Hooks: &HooksConfig{OnCreate: record("mapping"), OnKill: "exit 1"}},
+				},
+			},
+		},
+	}
+	m := &Manager{
+		tmuxConfig:  &cfg,
+		activeGroup: "default",
+		sessionsFile: TmuxSessionsFile{
+			Sessions: map[string]TmuxSessionConfig{},
+			Groups: map[string]GroupState{
+				"work": {Sessions: map[string]TmuxSessionConfig{"a": {Path: project}}},
+			},
+		},
+	}
+	m.SetActiveGroup("default")
+
+	if err := m.RunHooks(context.Background(), HookCreate, "proj", project); err != nil {
+		t.Fatalf("RunHooks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 hook runs, got %d: %q", len(lines), lines)
+	}
+	for i, level := range []string{"global", "group", "mapping"} {
+		want := level + " on_create proj work a " + project
+		if lines[i] != want {
+			t.Errorf("hook %d = %q, want %q", i, lines[i], want)
+		}
+	}
+
+	if err := m.RunHooks(context.Background(), HookKill, "proj", project); err == nil {
+		t.Error("expected the failing on_kill hook to be reported")
+	}
+}
+
+func TestRunHooksTimeoutKillsChildren(t *testing.T) {
+	// The shell forks sleep, which holds the output pipe open; killing
+	// only the shell would leave RunHooks waiting for it.
+	cfg := TmuxConfig{Hooks: &HooksConfig{OnSwitch: "sleep 5; true", Timeout: "50ms"}}
+	m := &Manager{tmuxConfig: &cfg, activeGroup: "default"}
+
+	start := time.Now()
+	err := m.RunHooks(context.Background(), HookSwitch, "proj", "")
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("RunHooks() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("RunHooks() returned after %s, want the hook killed at its timeout", elapsed)
+	}
+}
+
+func TestRunHooksTimeout(t *testing.T) {
+	cfg := TmuxConfig{Hooks: &HooksConfig{OnSwitch: "sleep 5", Timeout: "50ms"}}
+	m := &Manager{tmuxConfig: &cfg, activeGroup: "default"}
+
+	err := m.RunHooks(context.Background(), HookSwitch, "proj", "")
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("RunHooks() error = %v, want a timeout", err)
+	}
+}
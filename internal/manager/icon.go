@@ -0,0 +1,61 @@
+package manager
+
+import (
+	core_theme "github.com/grovetools/core/tui/theme"
+)
+
+// GroupIconGlyph returns the rendered icon of a group: its configured icon,
+// or the home icon for the default group and a starred folder otherwise.
+// This is what the TUIs show next to group names.
+func (m *Manager) GroupIconGlyph(group string) string {
+	icon := m.GetGroupIcon(group)
+	if group == "default" {
+		icon = m.GetDefaultIcon()
+	}
+	switch {
+	case icon != "":
+		return resolveIcon(icon)
+	case group == "default":
+		return core_theme.IconHome
+	default:
+		return core_theme.IconFolderStar
+	}
+}
+
+// resolveIcon converts a configured icon reference (a name token like
+// "tree" or a literal glyph) into the rendered glyph. Mirrors the helper in
+// pkg/tui/keymanage/view.go.
+func resolveIcon(iconRef string) string {
+	switch iconRef {
+	case "IconTree", "tree":
+		return core_theme.IconTree
+	case "IconProject", "project":
+		return core_theme.IconProject
+	case "IconRepo", "repo":
+		return core_theme.IconRepo
+	case "IconWorktree", "worktree":
+		return core_theme.IconWorktree
+	case "IconEcosystem", "ecosystem":
+		return core_theme.IconEcosystem
+	case "IconFolder", "folder":
+		return core_theme.IconFolder
+	case "IconFolderStar", "folder-star", "star":
+		return core_theme.IconFolderStar
+	case "IconHome", "home":
+		return core_theme.IconHome
+	case "IconCloud", "cloud":
+		return "󰅧"
+	case "IconCode", "code":
+		return core_theme.IconCode
+	case "IconBriefcase", "briefcase", "work":
+		return "󰃖"
+	case "IconKeyboard", "keyboard":
+		return core_theme.IconKeyboard
+	case "IconNote", "note":
+		return core_theme.IconNote
+	case "IconPlan", "plan":
+		return core_theme.IconPlan
+	default:
+		return iconRef
+	}
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LaunchSpec is a standalone session description read by `nav launch
+// --file`. It uses the same window and pane shape as nav.layouts, plus an
+// optional root directory and session-wide environment.
+type LaunchSpec struct {
+	Dir     string            `yaml:"dir,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+	Windows []LayoutWindow    `yaml:"windows"`
+}
+
+// ParseLaunchSpec decodes a YAML launch spec. Unknown fields are rejected
+// so typos such as "pane:" instead of "panes:" fail loudly rather than
+// silently producing an empty window.
+func ParseLaunchSpec(data []byte) (*LaunchSpec, error) {
+	var spec LaunchSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse launch spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// Layout returns the spec's windows as a LayoutConfig.
+func (s *LaunchSpec) Layout() *LayoutConfig {
+	return &LayoutConfig{Windows: s.Windows}
+}
+
+// Validate checks the spec against root, the directory the session will be
+// created in. Besides the layout checks it requires at least one window and
+// verifies every resolved working directory exists, since tmux silently
+// falls back to $HOME for a missing -c directory.
+func (s *LaunchSpec) Validate(root string) error {
+	if len(s.Windows) == 0 {
+		return fmt.Errorf("launch spec must declare at least one window")
+	}
+	if err := s.Layout().Validate(); err != nil {
+		return err
+	}
+	if err := validateEnvNames(s.Env); err != nil {
+		return err
+	}
+
+	dirs := []string{root}
+	for _, w := range s.Windows {
+		windowDir := resolveLayoutDir(root, w.Dir)
+		dirs = append(dirs, windowDir)
+		for _, p := range w.Panes {
+			dirs = append(dirs, resolveLayoutDir(windowDir, p.Dir))
+		}
+	}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("working directory %s: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("working directory %s is not a directory", dir)
+		}
+	}
+	return nil
+}
+
+// ResolveRoot returns the session root: override when set, otherwise the
+// spec's dir resolved against base, otherwise base itself.
+func (s *LaunchSpec) ResolveRoot(base, override string) string {
+	if override != "" {
+		return resolveLayoutDir(base, override)
+	}
+	return resolveLayoutDir(base, s.Dir)
+}
@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLaunchSpecRejectsUnknownFields(t *testing.T) {
+	_, err := ParseLaunchSpec([]byte("windows:\n  - name: dev\n    pane:\n      - command: nvim\n"))
+	if err == nil {
+		t.Fatal("expected an error for the misspelled 'pane' field")
+	}
+}
+
+func TestLaunchSpecValidate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{
+			name: "multi window spec",
+			spec: `
+windows:
+  - name: dev
+    panes:
+      - command: npx create-app@latest
+      - command: npm run dev
+        dir: web
+        split: horizontal
+        size: 30
+        env:
+          PORT: "3000"
+  - name: logs
+    focus: true
+`,
+		},
+		{name: "no windows", spec: "dir: .\n", wantErr: true},
+		{
+			name:    "missing pane directory",
+			spec:    "windows:\n  - panes:\n      - dir: does-not-exist\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid env name",
+			spec:    "windows:\n  - panes:\n      - env:\n          \"A=B\": x\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseLaunchSpec([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("ParseLaunchSpec() error = %v", err)
+			}
+			err = spec.Validate(root)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvFlagsAreSorted(t *testing.T) {
+	got := envFlags(map[string]string{"B": "2", "A": "1"})
+	want := []string{"-e", "A=1", "-e", "B=2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("envFlags() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,499 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// LayoutConfig declares the windows, pane splits, and startup commands nav
+// builds when it creates a project session for the first time. Layouts are
+// defined once under nav.layouts and referenced by name from default_layout,
+// a group's layout field, or a mapping's layout field.
+type LayoutConfig struct {
+	Windows []LayoutWindow `yaml:"windows,omitempty" toml:"windows,omitempty" jsonschema:"description=Windows to create in order. The first window reuses the session's initial window."`
+}
+
+// LayoutWindow is a single window in a layout.
+type LayoutWindow struct {
+	Name  string       `yaml:"name,omitempty" toml:"name,omitempty" jsonschema:"description=Window name"`
+	Dir   string       `yaml:"dir,omitempty" toml:"dir,omitempty" jsonschema:"description=Working directory. Relative paths resolve against the project root."`
+	Focus bool         `yaml:"focus,omitempty" toml:"focus,omitempty" jsonschema:"description=Select this window once the layout is built"`
+	Panes []LayoutPane `yaml:"panes,omitempty" toml:"panes,omitempty" jsonschema:"description=Panes in the window. The first pane is the window's initial pane; every later pane is split from the one before it."`
+}
+
+// LayoutPane is a single pane in a layout window.
+type LayoutPane struct {
+	Command string            `yaml:"command,omitempty" toml:"command,omitempty" jsonschema:"description=Command typed into the pane's shell on creation"`
+	Dir     string            `yaml:"dir,omitempty" toml:"dir,omitempty" jsonschema:"description=Working directory. Relative paths resolve against the window directory."`
+	Split   string            `yaml:"split,omitempty" toml:"split,omitempty" jsonschema:"description=How the pane is split from the previous pane. Ignored for the first pane.,enum=horizontal,enum=vertical"`
+	Size    int               `yaml:"size,omitempty" toml:"size,omitempty" jsonschema:"description=Size of the new pane as a percentage of the split pane (1-99)"`
+	Focus   bool              `yaml:"focus,omitempty" toml:"focus,omitempty" jsonschema:"description=Select this pane once the layout is built"`
+	Env     map[string]string `yaml:"env,omitempty" toml:"env,omitempty" jsonschema:"description=Environment variables set in the pane's shell"`
+}
+
+// Validate checks a layout for values tmux cannot apply. It is run before
+// any session is created so a bad layout never leaves a half-built session.
+// A nil layout is valid.
+func (l *LayoutConfig) Validate() error {
+	if l == nil {
+		return nil
+	}
+	focusedWindows := 0
+	for wi, w := range l.Windows {
+		if w.Focus {
+			focusedWindows++
+		}
+		focusedPanes := 0
+		for pi, p := range w.Panes {
+			if p.Focus {
+				focusedPanes++
+			}
+			if pi > 0 {
+				if _, err := splitFlag(p.Split); err != nil {
+					return fmt.Errorf("window %d pane %d: %w", wi, pi, err)
+				}
+			}
+			if p.Size < 0 || p.Size > 99 {
+				return fmt.Errorf("window %d pane %d: size %d must be 0 (unset) or 1-99", wi, pi, p.Size)
+			}
+			if err := validateEnvNames(p.Env); err != nil {
+				return fmt.Errorf("window %d pane %d: %w", wi, pi, err)
+			}
+		}
+		if focusedPanes > 1 {
+			return fmt.Errorf("window %d: only one pane may set focus", wi)
+		}
+	}
+	if focusedWindows > 1 {
+		return fmt.Errorf("only one window may set focus")
+	}
+	return nil
+}
+
+// splitFlag maps a layout split direction to the tmux split-window flag.
+// "horizontal" places panes side by side, "vertical" stacks them. An
+// unset direction defaults to vertical, matching tmux's own default.
+func splitFlag(split string) (string, error) {
+	switch strings.ToLower(split) {
+	case "", "v", "vertical":
+		return "-v", nil
+	case "h", "horizontal":
+		return "-h", nil
+	default:
+		return "", fmt.Errorf("invalid split %q (expected horizontal or vertical)", split)
+	}
+}
+
+// resolveLayoutDir resolves a layout directory against base. Empty dirs
+// inherit base, ~ is expanded, and relative paths are joined onto base.
+func resolveLayoutDir(base, dir string) string {
+	if dir == "" {
+		return base
+	}
+	dir = expandPath(dir)
+	if dir == "~" {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home
+		}
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(base, dir)
+}
+
+// GetLayout returns the named layout from nav.layouts.
+func (m *Manager) GetLayout(name string) (*LayoutConfig, bool) {
+	if m.tmuxConfig == nil || m.tmuxConfig.Layouts == nil {
+		return nil, false
+	}
+	layout, ok := m.tmuxConfig.Layouts[name]
+	if !ok {
+		return nil, false
+	}
+	return &layout, true
+}
+
+// FindMappingForPath returns the group and key whose mapping points exactly
+// at path. Unlike FindGroupForPath it does not match parent directories —
+// per-mapping settings only apply to the mapped project itself. The default
+// group wins when the same path is mapped in several groups.
+func (m *Manager) FindMappingForPath(path string) (group, key string, ok bool) {
+	target := lookupPath(path)
+
+	originalGroup := m.activeGroup
+	defer m.SetActiveGroup(originalGroup)
+
+	for _, g := range m.GetAllGroups() {
+		m.SetActiveGroup(g)
+		for k, sess := range m.sessions {
+			if sess.Path == "" {
+				continue
+			}
+			if lookupPath(sess.Path) == target {
+				return g, k, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// GetMappingOptions returns the nav-specific options for the project at
+// path in a group. The default group reads nav.mappings; other groups read
+// their own nav.groups.<name>.mappings table. Both are keyed by project
+// path, so the options follow a project to a new key and are not passed on
+// to the next project mapped to its old key.
+func (m *Manager) GetMappingOptions(group, path string) (SessionOptions, bool) {
+	if m.tmuxConfig == nil || path == "" {
+		return SessionOptions{}, false
+	}
+	var mappings map[string]SessionOptions
+	if group == "" || group == "default" {
+		mappings = m.tmuxConfig.Mappings
+	} else if ref, ok := m.tmuxConfig.Groups[group]; ok {
+		mappings = ref.Mappings
+	}
+	target := lookupPath(path)
+	for mappedPath, opts := range mappings {
+		if lookupPath(mappedPath) == target {
+			return opts, true
+		}
+	}
+	return SessionOptions{}, false
+}
+
+// lookupPath normalizes path for comparing mapped paths.
+func lookupPath(path string) string {
+	p, err := pathutil.NormalizeForLookup(expandPath(path))
+	if err != nil {
+		return filepath.Clean(expandPath(path))
+	}
+	return p
+}
+
+// ResolveLayout returns the layout that applies to a new session for path,
+// or nil when none is configured. Precedence is mapping layout, then group
+// layout, then nav.default_layout. Referencing an undefined layout, or one
+// that fails validation, is an error.
+func (m *Manager) ResolveLayout(path string) (*LayoutConfig, error) {
+	if m.tmuxConfig == nil {
+		return nil, nil
+	}
+
+	name := m.tmuxConfig.DefaultLayout
+	if group, key, ok := m.FindMappingForPath(path); ok {
+		if group != "default" {
+			if ref, exists := m.tmuxConfig.Groups[group]; exists && ref.Layout != "" {
+				name = ref.Layout
+			}
+		}
+		if opts, exists := m.GetMappingOptions(group, path); exists && opts.Layout != "" {
+			name = opts.Layout
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	layout, ok := m.GetLayout(name)
+	if !ok {
+		return nil, fmt.Errorf("layout %q is not defined in nav.layouts", name)
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("layout %q: %w", name, err)
+	}
+	return layout, nil
+}
+
+// LaunchSession creates a detached session named sessionName rooted at
+// path, building the resolved layout and environment when configured. Both
+// are resolved and validated before anything is created. Callers are
+// expected to have checked that the session does not already exist, and
+// to run HookCreate hooks once the session is up.
+func (m *Manager) LaunchSession(ctx context.Context, sessionName, path string) error {
+	expandedPath := expandPath(path)
+
+	layout, err := m.ResolveLayout(expandedPath)
+	if err != nil {
+		return err
+	}
+	env, err := m.ResolveEnv(expandedPath)
+	if err != nil {
+		return err
+	}
+	return LaunchLayout(ctx, m.muxEngine, sessionName, expandedPath, layout, env)
+}
+
+// HasSessionSetup reports whether a new session for path needs more than a
+// bare new-session, i.e. it has a layout, environment, or create/switch
+// hooks to apply. Callers that would otherwise exec an interactive
+// new-session use it to decide whether to build the session detached first.
+func (m *Manager) HasSessionSetup(path string) (bool, error) {
+	expandedPath := expandPath(path)
+	layout, err := m.ResolveLayout(expandedPath)
+	if err != nil {
+		return false, err
+	}
+	env, err := m.ResolveEnv(expandedPath)
+	if err != nil {
+		return false, err
+	}
+	return layout != nil || len(env) > 0 ||
+		m.HasHooks(HookCreate, expandedPath) || m.HasHooks(HookSwitch, expandedPath), nil
+}
+
+// LaunchLayout creates a detached session named sessionName rooted at root
+// and builds layout inside it. A nil layout creates a plain session. env is
+// set on the session with new-session -e, so every pane inherits it. The
+// layout is validated before anything is created, and a session that fails
+// partway through its layout is killed again. engine may be nil, in which
+// case the session is created with tmux new-session directly.
+func LaunchLayout(ctx context.Context, engine mux.MuxEngine, sessionName, root string, layout *LayoutConfig, env map[string]string) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if err := validateEnvNames(env); err != nil {
+		return err
+	}
+
+	opts := mux.LaunchOptions{
+		SessionName:      sessionName,
+		WorkingDirectory: root,
+	}
+	var firstEnv map[string]string
+	if layout != nil {
+		opts = layout.launchOptions(sessionName, root)
+		if len(layout.Windows) > 0 && len(layout.Windows[0].Panes) > 0 {
+			firstEnv = layout.Windows[0].Panes[0].Env
+		}
+	}
+
+	// The first pane's command is typed in by hand when the pane has to be
+	// respawned with its own environment, or when the engine is bypassed.
+	var firstCommand string
+	if len(opts.Panes) > 0 {
+		firstCommand = opts.Panes[0].Command
+	}
+
+	// mux.LaunchOptions has no way to pass environment, so sessions with env
+	// are created through new-session -e instead of the engine.
+	if engine != nil && len(env) == 0 && len(firstEnv) == 0 {
+		if err := engine.Launch(ctx, opts); err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+		firstCommand = ""
+	} else {
+		// No engine (typically no server yet) — new-session -d starts one.
+		args := []string{"new-session", "-d", "-s", sessionName, "-c", opts.WorkingDirectory}
+		if opts.WindowName != "" {
+			args = append(args, "-n", opts.WindowName)
+		}
+		args = append(args, envFlags(env)...)
+		if output, err := tmuxCommand(args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create session: %s", strings.TrimSpace(string(output)))
+		}
+	}
+
+	// Pane env must not leak into the session environment, so the first
+	// pane is restarted with its own -e flags instead.
+	if len(firstEnv) > 0 {
+		args := append([]string{"respawn-pane", "-k", "-t", sessionName + ":", "-c", opts.WorkingDirectory}, envFlags(firstEnv)...)
+		if output, err := tmuxCommand(args...).CombinedOutput(); err != nil {
+			killSession(sessionName)
+			return fmt.Errorf("failed to set pane environment: %s", strings.TrimSpace(string(output)))
+		}
+	}
+	if firstCommand != "" {
+		_ = tmuxCommand("send-keys", "-t", sessionName+":", firstCommand, "Enter").Run()
+	}
+
+	// Mark the session as nav-created so `nav session save` picks it up.
+	_ = tmuxCommand("set-option", "-t", sessionName, navSessionOption, "1").Run()
+
+	if layout == nil {
+		return nil
+	}
+	if err := applyLayout(sessionName, root, layout); err != nil {
+		killSession(sessionName)
+		return err
+	}
+	return nil
+}
+
+// killSession removes a session nav could not finish building, so a
+// failed launch or restore never leaves a half-built session behind.
+func killSession(sessionName string) {
+	_ = tmuxCommand("kill-session", "-t", "="+sessionName).Run()
+}
+
+// envFlags converts an environment map into sorted tmux -e flags so the
+// generated command line is deterministic.
+func envFlags(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+env[k])
+	}
+	return args
+}
+
+// launchOptions converts the first window of the layout into the
+// mux.LaunchOptions used to create the session. mux.LaunchOptions can only
+// describe a single window without split geometry, so the remaining panes
+// and windows are added afterwards by applyLayout.
+func (l *LayoutConfig) launchOptions(sessionName, root string) mux.LaunchOptions {
+	opts := mux.LaunchOptions{
+		SessionName:      sessionName,
+		WorkingDirectory: root,
+	}
+	if len(l.Windows) == 0 {
+		return opts
+	}
+	first := l.Windows[0]
+	opts.WindowName = first.Name
+	opts.WorkingDirectory = resolveLayoutDir(root, first.Dir)
+	if len(first.Panes) == 0 {
+		return opts
+	}
+	// The session starts in the first pane, so it starts in that pane's
+	// directory whether or not the pane has a command.
+	opts.WorkingDirectory = resolveLayoutDir(opts.WorkingDirectory, first.Panes[0].Dir)
+	if first.Panes[0].Command != "" {
+		opts.Panes = []mux.PaneOptions{{
+			Command:          first.Panes[0].Command,
+			WorkingDirectory: opts.WorkingDirectory,
+		}}
+	}
+	return opts
+}
+
+// applyLayout builds everything launchOptions could not express: the
+// splits of the first window, every later window, and the final focus.
+// All commands go through tmuxCommand so GROVE_TMUX_SOCKET is honored.
+func applyLayout(sessionName, root string, layout *LayoutConfig) error {
+	var focusWindow, focusPane string
+
+	for wi, w := range layout.Windows {
+		windowID, paneID, err := buildLayoutWindow(sessionName, root, fmt.Sprintf("layout window %d", wi), w, wi == 0)
+		if err != nil {
+			return err
+		}
+		if paneID != "" {
+			focusPane = paneID
+		}
+		if w.Focus {
+			focusWindow = windowID
+		}
+	}
+
+	if focusWindow == "" && focusPane != "" {
+		focusWindow, _ = tmuxOutput("display-message", "-p", "-t", focusPane, "#{window_id}")
+	}
+	if focusWindow != "" {
+		if err := tmuxCommand("select-window", "-t", focusWindow).Run(); err != nil {
+			return fmt.Errorf("failed to focus layout window: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildLayoutWindow builds layout window w in sessionName and returns its
+// ID and the ID of its focused pane, if any; label names the window in
+// errors. With initial set the session's initial window is reused, its
+// first pane having already received its command through
+// mux.LaunchOptions. Otherwise a new window is created in the background.
+func buildLayoutWindow(sessionName, root, label string, w LayoutWindow, initial bool) (windowID, focusPane string, err error) {
+	windowDir := resolveLayoutDir(root, w.Dir)
+
+	if initial {
+		windowID, err = tmuxOutput("display-message", "-p", "-t", sessionName, "#{window_id}")
+	} else {
+		args := []string{"new-window", "-d", "-t", sessionName + ":", "-c", windowDir, "-P", "-F", "#{window_id}"}
+		if w.Name != "" {
+			args = append(args, "-n", w.Name)
+		}
+		if len(w.Panes) > 0 {
+			args = append(args, envFlags(w.Panes[0].Env)...)
+		}
+		windowID, err = tmuxOutput(args...)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", label, err)
+	}
+
+	prevPane, err := tmuxOutput("display-message", "-p", "-t", windowID, "#{pane_id}")
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", label, err)
+	}
+
+	for pi, p := range w.Panes {
+		paneID := prevPane
+		if pi > 0 {
+			flag, _ := splitFlag(p.Split) // validated by ResolveLayout
+			args := []string{"split-window", flag, "-d", "-t", prevPane, "-c", resolveLayoutDir(windowDir, p.Dir), "-P", "-F", "#{pane_id}"}
+			if p.Size > 0 {
+				args = append(args, "-l", fmt.Sprintf("%d%%", p.Size))
+			}
+			args = append(args, envFlags(p.Env)...)
+			paneID, err = tmuxOutput(args...)
+			if err != nil {
+				return "", "", fmt.Errorf("%s pane %d: %w", label, pi, err)
+			}
+		}
+		if p.Command != "" && (!initial || pi > 0) {
+			if err := tmuxCommand("send-keys", "-t", paneID, p.Command, "Enter").Run(); err != nil {
+				return "", "", fmt.Errorf("%s pane %d: failed to send command: %w", label, pi, err)
+			}
+		}
+		if p.Focus {
+			if err := tmuxCommand("select-pane", "-t", paneID).Run(); err != nil {
+				return "", "", fmt.Errorf("%s pane %d: failed to focus pane: %w", label, pi, err)
+			}
+			focusPane = paneID
+		}
+		prevPane = paneID
+	}
+	return windowID, focusPane, nil
+}
+
+// WindowTemplate returns the window named name in the layout that applies
+// to path, for creating that window on its own in an existing session.
+func (m *Manager) WindowTemplate(path, name string) (*LayoutWindow, error) {
+	layout, err := m.ResolveLayout(expandPath(path))
+	if err != nil || layout == nil {
+		return nil, err
+	}
+	for _, w := range layout.Windows {
+		if w.Name == name {
+			return &w, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddLayoutWindow builds a single layout window, with its panes and
+// commands, in the existing session sessionName rooted at root.
+func AddLayoutWindow(sessionName, root string, w LayoutWindow) error {
+	_, _, err := buildLayoutWindow(sessionName, root, fmt.Sprintf("layout window %q", w.Name), w, false)
+	return err
+}
+
+// tmuxOutput runs a tmux command and returns its trimmed stdout.
+func tmuxOutput(args ...string) (string, error) {
+	output, err := tmuxCommand(args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("tmux %s: %s", args[0], strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
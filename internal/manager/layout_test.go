@@ -0,0 +1,220 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/grovetools/core/pkg/mux"
+)
+
+func TestLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  LayoutConfig
+		wantErr bool
+	}{
+		{
+			name: "valid editor and tests split",
+			layout: LayoutConfig{Windows: []LayoutWindow{{
+				Name: "dev",
+				Panes: []LayoutPane{
+					{Command: "nvim ."},
+					{Command: "go test ./...", Split: "horizontal", Size: 30},
+				},
+			}}},
+		},
+		{
+			name: "split on first pane is ignored",
+			layout: LayoutConfig{Windows: []LayoutWindow{{
+				Panes: []LayoutPane{{Split: "diagonal"}},
+			}}},
+		},
+		{
+			name: "invalid split direction",
+			layout: LayoutConfig{Windows: []LayoutWindow{{
+				Panes: []LayoutPane{{}, {Split: "diagonal"}},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "size out of range",
+			layout: LayoutConfig{Windows: []LayoutWindow{{
+				Panes: []LayoutPane{{}, {Size: 100}},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "two focused windows",
+			layout: LayoutConfig{Windows: []LayoutWindow{
+				{Focus: true},
+				{Focus: true},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.layout.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLayoutLaunchOptionsUsesFirstWindow(t *testing.T) {
+	layout := LayoutConfig{Windows: []LayoutWindow{
+		{Name: "editor", Dir: "src", Panes: []LayoutPane{{Command: "nvim ."}, {Command: "make watch"}}},
+		{Name: "logs"},
+	}}
+
+	opts := layout.launchOptions("proj", "/work/proj")
+	if opts.SessionName != "proj" {
+		t.Errorf("SessionName = %q, want %q", opts.SessionName, "proj")
+	}
+	if opts.WindowName != "editor" {
+		t.Errorf("WindowName = %q, want %q", opts.WindowName, "editor")
+	}
+	if opts.WorkingDirectory != filepath.Join("/work/proj", "src") {
+		t.Errorf("WorkingDirectory = %q, want relative dir resolved against root", opts.WorkingDirectory)
+	}
+	if len(opts.Panes) != 1 || opts.Panes[0].Command != "nvim ." {
+		t.Errorf("Panes = %+v, want only the first pane's command", opts.Panes)
+	}
+}
+
+func TestLayoutLaunchOptionsFirstPaneDir(t *testing.T) {
+	layout := LayoutConfig{Windows: []LayoutWindow{
+		{Dir: "src", Panes: []LayoutPane{{Dir: "cmd"}, {Command: "make watch"}}},
+	}}
+
+	opts := layout.launchOptions("proj", "/work/proj")
+	if want := filepath.Join("/work/proj", "src", "cmd"); opts.WorkingDirectory != want {
+		t.Errorf("WorkingDirectory = %q, want %q", opts.WorkingDirectory, want)
+	}
+	if len(opts.Panes) != 0 {
+		t.Errorf("Panes = %+v, want none for a first pane without a command", opts.Panes)
+	}
+}
+
+func TestLaunchLayoutKillsSessionOnFailure(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+	t.Setenv(mux.EnvGroveTmuxSocket, fmt.Sprintf("nav-layout-test-%d", os.Getpid()))
+	t.Cleanup(func() { _ = tmuxCommand("kill-server").Run() })
+
+	// A detached session is 80x24, too small for this many stacked panes,
+	// so split-window fails partway through the layout.
+	layout := &LayoutConfig{Windows: []LayoutWindow{{Panes: make([]LayoutPane, 40)}}}
+	if err := LaunchLayout(context.Background(), nil, "half-built", t.TempDir(), layout, nil); err == nil {
+		t.Fatal("LaunchLayout() succeeded, want a split-window error")
+	}
+	if err := tmuxCommand("has-session", "-t", "=half-built").Run(); err == nil {
+		t.Error("failed launch left its session running")
+	}
+}
+
+func TestResolveLayoutPrecedence(t *testing.T) {
+	mapped := t.TempDir()
+	other := t.TempDir()
+	unmapped := t.TempDir()
+
+	cfg := TmuxConfig{
+		DefaultLayout: "base",
+		Layouts: map[string]LayoutConfig{
+			"base":  {Windows: []LayoutWindow{{Name: "base"}}},
+			"group": {Windows: []LayoutWindow{{Name: "group"}}},
+			"key":   {Windows: []LayoutWindow{{Name: "key"}}},
+		},
+		Groups: map[string]GroupRef{
+			"work": {
+				Prefix:   "<prefix> w",
+				Layout:   "group",
+				Mappings: map[string]SessionOptions{mapped: {Layout: "key"}},
+			},
+		},
+	}
+	cfg.ApplyDefaults()
+
+	newManager := func(path string) *Manager {
+		m := &Manager{
+			tmuxConfig:  &cfg,
+			activeGroup: "default",
+			sessionsFile: TmuxSessionsFile{
+				Sessions: map[string]TmuxSessionConfig{},
+				Groups: map[string]GroupState{
+					"work": {Sessions: map[string]TmuxSessionConfig{"a": {Path: path}}},
+				},
+			},
+		}
+		m.SetActiveGroup("default")
+		return m
+	}
+
+	tests := []struct {
+		name string
+		mgr  *Manager
+		path string
+		want string
+	}{
+		{name: "unmapped path uses default layout", mgr: newManager(mapped), path: unmapped, want: "base"},
+		{name: "mapping layout beats group layout", mgr: newManager(mapped), path: mapped, want: "key"},
+		{name: "group layout beats default layout", mgr: newManager(other), path: other, want: "group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := tt.mgr.ResolveLayout(tt.path)
+			if err != nil {
+				t.Fatalf("ResolveLayout() error = %v", err)
+			}
+			if layout == nil || layout.Windows[0].Name != tt.want {
+				t.Errorf("ResolveLayout() = %+v, want layout %q", layout, tt.want)
+			}
+		})
+	}
+}
+
+func TestMappingOptionsFollowMovedKey(t *testing.T) {
+	moved := t.TempDir()
+	next := t.TempDir()
+
+	cfg := TmuxConfig{
+		Mappings: map[string]SessionOptions{moved: {Layout: "custom"}},
+	}
+	// moved was on "a" when its options were written; it now sits on "b"
+	// and next has taken over "a".
+	m := &Manager{
+		tmuxConfig:  &cfg,
+		activeGroup: "default",
+		sessionsFile: TmuxSessionsFile{
+			Sessions: map[string]TmuxSessionConfig{
+				"a": {Path: next},
+				"b": {Path: moved},
+			},
+		},
+	}
+	m.SetActiveGroup("default")
+
+	if opts, ok := m.GetMappingOptions("default", moved); !ok || opts.Layout != "custom" {
+		t.Errorf("GetMappingOptions(moved) = %+v, %v; want layout %q", opts, ok, "custom")
+	}
+	if opts, ok := m.GetMappingOptions("default", next); ok {
+		t.Errorf("GetMappingOptions(next) = %+v, want no options for the project now on the old key", opts)
+	}
+}
+
+func TestResolveLayoutUnknownName(t *testing.T) {
+	cfg := TmuxConfig{DefaultLayout: "missing"}
+	m := &Manager{tmuxConfig: &cfg, activeGroup: "default"}
+
+	if _, err := m.ResolveLayout(t.TempDir()); err == nil {
+		t.Fatal("expected an error for a layout that is not defined")
+	}
+}
@@ -41,7 +41,8 @@ type Manager struct {
 	sessionsFile  TmuxSessionsFile
 	undoStack     [][]byte
 	redoStack     [][]byte
-	daemonClient  daemon.Client // Daemon client for persisting bindings (uses LocalClient fallback when daemon is not running)
+	daemonClient  daemon.Client              // Daemon client for persisting bindings (uses LocalClient fallback when daemon is not running)
+	projectNodes  []*workspace.WorkspaceNode // Discovered projects session names are resolved against, loaded by KnownProjectNodes
 }
 
 // managerState captures the full state for undo/redo operations
@@ -865,6 +866,15 @@ func (m *Manager) GetDefaultIcon() string {
 	return m.tmuxConfig.DefaultIcon
 }
 
+// GetHistoryPrefix returns the configured prefix for history-slot hotkeys,
+// or "" when they are disabled.
+func (m *Manager) GetHistoryPrefix() string {
+	if m.tmuxConfig == nil {
+		return ""
+	}
+	return m.tmuxConfig.HistoryPrefix
+}
+
 func (m *Manager) GetSessions() ([]models.TmuxSession, error) {
 	if m.tmuxConfig == nil {
 		return []models.TmuxSession{}, nil
@@ -892,6 +902,22 @@ func (m *Manager) GetSessions() ([]models.TmuxSession, error) {
 		}
 	}
 
+	// Chords ("gw") are not listed in available_keys; show the mapped ones
+	// after the single-key slots.
+	var chords []string
+	for key, sessionData := range m.sessions {
+		if sessionData.Path != "" && navbindings.IsChord(key) {
+			chords = append(chords, key)
+		}
+	}
+	sort.Strings(chords)
+	for _, key := range chords {
+		sessions = append(sessions, models.TmuxSession{
+			Key:  key,
+			Path: expandPath(m.sessions[key].Path),
+		})
+	}
+
 	return sessions, nil
 }
 
@@ -974,7 +1000,7 @@ func (m *Manager) updateTOMLSessions(content, group string, sessions map[string]
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		sessionLines = append(sessionLines, fmt.Sprintf("%s = %q", k, sessions[k].Path))
+		sessionLines = append(sessionLines, fmt.Sprintf("%s = %q", tomlKey(k), sessions[k].Path))
 	}
 	newSessionsContent := strings.Join(sessionLines, "\n")
 
@@ -1025,6 +1051,17 @@ func (m *Manager) updateTOMLSessions(content, group string, sessions map[string]
 	return content + "\n" + sessionsHeader + "\n" + newSessionsContent + "\n"
 }
 
+// tomlKey renders a session key as a TOML key, quoting keys that are not
+// bare (e.g. space-separated chords like "g C-a").
+func tomlKey(key string) string {
+	for _, r := range key {
+		if !(r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
 // saveStaticConfigFull is the fallback that rewrites the entire config file.
 // Used for YAML files or when surgical editing isn't possible.
 func (m *Manager) saveStaticConfigFull() error {
@@ -1082,6 +1119,25 @@ func (m *Manager) saveStaticConfigFull() error {
 		if groupCfg.Order != 0 {
 			groupMap["order"] = groupCfg.Order
 		}
+		// Save layout if set
+		if groupCfg.Layout != "" {
+			groupMap["layout"] = groupCfg.Layout
+		}
+		// Save group environment defaults if set
+		if len(groupCfg.Env) > 0 {
+			groupMap["env"] = groupCfg.Env
+		}
+		if groupCfg.EnvFile != "" {
+			groupMap["env_file"] = groupCfg.EnvFile
+		}
+		// Save group lifecycle hooks if set
+		if groupCfg.Hooks != nil {
+			groupMap["hooks"] = groupCfg.Hooks
+		}
+		// Save per-key mapping options if any
+		if len(groupCfg.Mappings) > 0 {
+			groupMap["mappings"] = groupCfg.Mappings
+		}
 		// Save sessions if any
 		if len(groupCfg.Sessions) > 0 {
 			sessionsMap := make(map[string]string)
@@ -1581,7 +1637,7 @@ func (m *Manager) Sessionize(path string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get project info: %w", err)
 	}
-	sessionName := projInfo.Identifier("_")
+	sessionName := m.SessionName(projInfo)
 
 	ctx := context.Background()
 
@@ -1591,42 +1647,57 @@ func (m *Manager) Sessionize(path string) error {
 
 	// If tmux is not running and we're not in tmux, start new session
 	if !tmuxRunning && !inTmux {
-		// Need to use exec.Command directly for interactive session
-		// Use tmuxCommand to respect GROVE_TMUX_SOCKET
-		cmd := tmuxCommand("new-session", "-s", sessionName, "-c", expandedPath)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
-	}
-
-	// Check if mux engine is available
-	if m.muxEngine == nil {
-		return fmt.Errorf("mux engine not initialized")
-	}
-
-	// Check if session already exists
-	exists, err := m.muxEngine.SessionExists(ctx, sessionName)
-	if err != nil {
-		return fmt.Errorf("failed to check session: %w", err)
-	}
+		setup, err := m.HasSessionSetup(expandedPath)
+		if err != nil {
+			return err
+		}
+		if !setup {
+			// Need to use exec.Command directly for interactive session
+			// Use tmuxCommand to respect GROVE_TMUX_SOCKET
+			cmd := tmuxCommand("new-session", "-s", sessionName, "-c", expandedPath)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+		// Layouts, env and hooks are built detached and attached below.
+		if err := m.LaunchSession(ctx, sessionName, expandedPath); err != nil {
+			return err
+		}
+		m.RunHooksReportingErrors(ctx, HookCreate, sessionName, expandedPath, false)
+	} else {
+		// Check if mux engine is available
+		if m.muxEngine == nil {
+			return fmt.Errorf("mux engine not initialized")
+		}
 
-	if !exists {
-		// Create new detached session using the mux engine
-		opts := mux.LaunchOptions{
-			SessionName:      sessionName,
-			WorkingDirectory: expandedPath,
+		// Check if session already exists
+		exists, err := m.muxEngine.SessionExists(ctx, sessionName)
+		if err != nil {
+			return fmt.Errorf("failed to check session: %w", err)
 		}
-		if err := m.muxEngine.Launch(ctx, opts); err != nil {
-			return fmt.Errorf("failed to create session: %w", err)
+
+		if !exists {
+			// Create new detached session, building its layout if configured
+			if err := m.LaunchSession(ctx, sessionName, expandedPath); err != nil {
+				return err
+			}
+			m.RunHooksReportingErrors(ctx, HookCreate, sessionName, expandedPath, false)
 		}
 	}
 
 	// Switch to the session if we're in tmux
 	if inTmux {
-		return m.muxEngine.SwitchSession(ctx, sessionName, "")
+		if err := m.muxEngine.SwitchSession(ctx, sessionName, ""); err != nil {
+			return err
+		}
+		m.RunHooksReportingErrors(ctx, HookSwitch, sessionName, expandedPath, false)
+		return nil
 	}
 
+	// Attaching blocks until detach, so on_switch runs first.
+	m.RunHooksReportingErrors(ctx, HookSwitch, sessionName, expandedPath, false)
+
 	// Attach to the session if we're outside tmux
 	// Need to use exec.Command directly for interactive attach
 	// Use tmuxCommand to respect GROVE_TMUX_SOCKET
@@ -1647,9 +1718,38 @@ func (m *Manager) isTmuxRunning() bool {
 }
 
 // RegenerateBindingsGo generates tmux key bindings in Go (replacing Python script).
-// It processes all configured workspace groups and generates separate binding files.
-// Delegates to nav/pkg/bindings.GenerateTmuxConf for the actual generation.
+// It processes all configured workspace groups and generates the default
+// binding targets (tmux and tuimux) so nav works regardless of which mux is
+// active at runtime.
 func (m *Manager) RegenerateBindingsGo() error {
+	return m.RegenerateBindingsFor(navbindings.DefaultTargets())
+}
+
+// RegenerateBindingsFor generates bindings for the named targets (see
+// nav/pkg/bindings.Targets). An empty list selects the default targets.
+func (m *Manager) RegenerateBindingsFor(targets []string) error {
+	plan, err := m.PlanBindings(targets)
+	if err != nil {
+		return err
+	}
+	return plan.Apply()
+}
+
+// PlanBindings renders the bindings for targets in memory and returns the
+// changes regenerating would make, without touching disk.
+func (m *Manager) PlanBindings(targets []string) (*navbindings.Plan, error) {
+	opts := navbindings.Options{
+		BinDir:        paths.BinDir(),
+		CacheDir:      paths.CacheDir(),
+		SkipMissing:   true,
+		HistoryPrefix: m.GetHistoryPrefix(),
+	}
+	return navbindings.BuildPlan(m.GroupBindings(), opts, targets)
+}
+
+// GroupBindings resolves every group's prefix and sessions into the form
+// consumed by the nav/pkg/bindings generators.
+func (m *Manager) GroupBindings() []navbindings.GroupBinding {
 	// Preserve current active group
 	originalGroup := m.activeGroup
 	defer m.SetActiveGroup(originalGroup)
@@ -1663,26 +1763,46 @@ func (m *Manager) RegenerateBindingsGo() error {
 
 		// Build session map from the Manager's internal state
 		sessionMap := make(map[string]models.NavSessionConfig)
+		actions := make(map[string]navbindings.Action)
 		for key, sess := range m.sessions {
 			sessionMap[key] = sess
+			if opts, ok := m.GetMappingOptions(group, sess.Path); ok {
+				actions[key] = opts.BindingAction()
+			}
 		}
 
 		groupBindings = append(groupBindings, navbindings.GroupBinding{
 			Name:     group,
 			Prefix:   prefix,
 			Sessions: sessionMap,
+			Actions:  actions,
+			Icon:     m.GroupIconGlyph(group),
 		})
 	}
+	return groupBindings
+}
 
-	binDir := paths.BinDir()
-	cacheDir := paths.CacheDir()
+// ValidateBindings runs the full binding validation over every active
+// group and returns all issues, including warnings such as missing paths.
+func (m *Manager) ValidateBindings() *navbindings.Report {
+	file, groupConfigs := m.bindingsFile()
+	return navbindings.ValidateReport(nil, file, groupConfigs)
+}
 
-	// Generate both tmux and tuimux bindings so nav works regardless
-	// of which mux is active at runtime.
-	if err := navbindings.GenerateTmuxConf(groupBindings, binDir, cacheDir); err != nil {
-		return err
+// bindingsFile collects the mappings and prefixes of every active group in
+// the form the pkg/bindings validators take.
+func (m *Manager) bindingsFile() (*models.NavSessionsFile, map[string]navbindings.GroupConfig) {
+	file := &models.NavSessionsFile{Groups: make(map[string]models.NavGroupState)}
+	groupConfigs := make(map[string]navbindings.GroupConfig)
+	for _, g := range m.GroupBindings() {
+		groupConfigs[g.Name] = navbindings.GroupConfig{Prefix: g.Prefix}
+		if g.Name == "default" {
+			file.Sessions = g.Sessions
+			continue
+		}
+		file.Groups[g.Name] = models.NavGroupState{Sessions: g.Sessions}
 	}
-	return navbindings.GenerateTuimuxConf(groupBindings, binDir, cacheDir)
+	return file, groupConfigs
 }
 
 // DetectTmuxKeyForPath detects the tmux session key for a given working directory
@@ -1721,6 +1841,21 @@ func (m *Manager) GetAvailableKeys() []string {
 	return m.tmuxConfig.AvailableKeys
 }
 
+// IsValidKey reports whether key can be bound: one of the available keys,
+// or a chord made only of available keys.
+func (m *Manager) IsValidKey(key string) bool {
+	available := make(map[string]bool)
+	for _, k := range m.GetAvailableKeys() {
+		available[k] = true
+	}
+	for _, step := range navbindings.KeySteps(key) {
+		if !available[step] {
+			return false
+		}
+	}
+	return key != ""
+}
+
 // UpdateSessionKey updates the key for a specific session
 func (m *Manager) UpdateSessionKey(oldKey, newKey string) error {
 	if oldKey == newKey {
@@ -1738,14 +1873,7 @@ func (m *Manager) UpdateSessionKey(oldKey, newKey string) error {
 	}
 
 	// Check if new key is valid
-	validKey := false
-	for _, k := range m.tmuxConfig.AvailableKeys {
-		if k == newKey {
-			validKey = true
-			break
-		}
-	}
-	if !validKey {
+	if !m.IsValidKey(newKey) {
 		return fmt.Errorf("'%s' is not a valid key", newKey)
 	}
 
@@ -1754,6 +1882,17 @@ func (m *Manager) UpdateSessionKey(oldKey, newKey string) error {
 		return fmt.Errorf("key '%s' is already in use", newKey)
 	}
 
+	// A chord may not share its leading keys with another bound key.
+	var bound []string
+	for k, s := range m.sessions {
+		if k != oldKey && s.Path != "" {
+			bound = append(bound, k)
+		}
+	}
+	if other, conflict := navbindings.ChordConflict(newKey, bound); conflict {
+		return fmt.Errorf("key '%s' is ambiguous with bound key '%s'", navbindings.FormatKey(newKey), navbindings.FormatKey(other))
+	}
+
 	// Update the session key
 	m.sessions[newKey] = session
 	delete(m.sessions, oldKey)
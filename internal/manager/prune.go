@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// PruneCandidate is an idle session considered by FindIdleSessions. Skip
+// is set when the session is idle but must be kept.
+type PruneCandidate struct {
+	SessionInfo
+	LastUsed time.Time `json:"last_used"`
+	Skip     string    `json:"skip,omitempty"`
+}
+
+// paneCommandsFunc returns the foreground command of every pane in a session.
+type paneCommandsFunc func(sessionName string) ([]string, error)
+
+// FindIdleSessions returns the sessions that have not been used for at
+// least idle, most idle first. A session's last use is the later of its
+// tmux activity timestamp and the access-history entry for its path.
+// Attached sessions, sessions mapped to a locked key, and sessions with a
+// non-shell foreground process are returned with Skip set.
+func (m *Manager) FindIdleSessions(idle time.Duration, now time.Time) ([]PruneCandidate, error) {
+	sessions, err := m.ListSessionInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	lastAccess := make(map[string]time.Time)
+	if history, err := m.GetAccessHistory(); err == nil && history != nil {
+		for path, access := range history.Projects {
+			if access == nil {
+				continue
+			}
+			if access.Path != "" {
+				path = access.Path
+			}
+			lastAccess[normalizePrunePath(path)] = access.LastAccessed
+		}
+	}
+
+	locked := make(map[string]bool, len(m.lockedKeys))
+	for _, k := range m.lockedKeys {
+		locked[k] = true
+	}
+
+	return selectIdleSessions(sessions, lastAccess, locked, sessionPaneCommands, idle, now), nil
+}
+
+// selectIdleSessions applies the prune rules to sessions. paneCommands is
+// only consulted for sessions that are otherwise eligible.
+func selectIdleSessions(sessions []SessionInfo, lastAccess map[string]time.Time, locked map[string]bool, paneCommands paneCommandsFunc, idle time.Duration, now time.Time) []PruneCandidate {
+	var candidates []PruneCandidate
+	for _, s := range sessions {
+		lastUsed := s.LastActivity
+		if s.Path != "" {
+			if t := lastAccess[normalizePrunePath(s.Path)]; t.After(lastUsed) {
+				lastUsed = t
+			}
+		}
+		if lastUsed.IsZero() || now.Sub(lastUsed) < idle {
+			continue
+		}
+
+		c := PruneCandidate{SessionInfo: s, LastUsed: lastUsed}
+		switch {
+		case s.Attached > 0:
+			c.Skip = "attached"
+		case s.Key != "" && locked[s.Key]:
+			c.Skip = fmt.Sprintf("locked key %s", s.Key)
+		default:
+			commands, err := paneCommands(s.Name)
+			if err != nil {
+				c.Skip = "could not inspect panes"
+				break
+			}
+			for _, cmd := range commands {
+				if cmd != "" && !IsShellCommand(cmd) {
+					c.Skip = "running " + cmd
+					break
+				}
+			}
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+	return candidates
+}
+
+// sessionPaneCommands lists the foreground command of every pane in a
+// tmux session.
+func sessionPaneCommands(sessionName string) ([]string, error) {
+	output, err := tmuxOutput("list-panes", "-s", "-t", "="+sessionName+":", "-F", "#{pane_current_command}")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// normalizePrunePath normalizes a path for comparing session roots with
+// access-history entries.
+func normalizePrunePath(path string) string {
+	p, err := pathutil.NormalizeForLookup(expandPath(path))
+	if err != nil {
+		return filepath.Clean(expandPath(path))
+	}
+	return p
+}
@@ -0,0 +1,69 @@
+package manager
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSelectIdleSessions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	sessions := []SessionInfo{
+		{Name: "fresh", Path: "/p/fresh", LastActivity: ago(time.Hour)},
+		{Name: "old", Path: "/p/old", LastActivity: ago(10 * time.Hour)},
+		{Name: "older", Path: "/p/older", LastActivity: ago(20 * time.Hour)},
+		{Name: "recently-opened", Path: "/p/opened", LastActivity: ago(10 * time.Hour)},
+		{Name: "attached", Path: "/p/attached", Attached: 1, LastActivity: ago(10 * time.Hour)},
+		{Name: "locked", Path: "/p/locked", Key: "a", LastActivity: ago(10 * time.Hour)},
+		{Name: "editor", Path: "/p/editor", LastActivity: ago(10 * time.Hour)},
+		{Name: "broken", Path: "/p/broken", LastActivity: ago(10 * time.Hour)},
+	}
+	lastAccess := map[string]time.Time{
+		"/p/opened": ago(30 * time.Minute),
+		"/p/old":    ago(12 * time.Hour),
+	}
+	locked := map[string]bool{"a": true}
+	panes := func(name string) ([]string, error) {
+		switch name {
+		case "editor":
+			return []string{"zsh", "nvim"}, nil
+		case "broken":
+			return nil, errors.New("no such session")
+		}
+		return []string{"zsh", "-bash"}, nil
+	}
+
+	got := selectIdleSessions(sessions, lastAccess, locked, panes, 6*time.Hour, now)
+
+	want := map[string]string{
+		"old":      "",
+		"older":    "",
+		"attached": "attached",
+		"locked":   "locked key a",
+		"editor":   "running nvim",
+		"broken":   "could not inspect panes",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates, want %d: %+v", len(got), len(want), got)
+	}
+	for _, c := range got {
+		skip, ok := want[c.Name]
+		if !ok {
+			t.Errorf("unexpected candidate %q", c.Name)
+			continue
+		}
+		if c.Skip != skip {
+			t.Errorf("%s: skip = %q, want %q", c.Name, c.Skip, skip)
+		}
+	}
+	if got[0].Name != "older" {
+		t.Errorf("expected the most idle session first, got %q", got[0].Name)
+	}
+	for _, c := range got {
+		if c.Name == "old" && !c.LastUsed.Equal(ago(10*time.Hour)) {
+			t.Errorf("old: last used = %v, want the later tmux activity", c.LastUsed)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nav/pkg/api"
+)
+
+// SessionInfo describes a running tmux session and the nav mapping (if
+// any) whose path it is rooted at.
+type SessionInfo struct {
+	Name         string    `json:"name"`
+	Path         string    `json:"path"`
+	Group        string    `json:"group,omitempty"`
+	Key          string    `json:"key,omitempty"`
+	Attached     int       `json:"attached"`
+	Windows      int       `json:"windows"`
+	Created      time.Time `json:"created"`
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// sessionInfoFormat is the list-sessions format parsed by parseSessionInfo.
+// Fields are tab separated; the session name goes last since it is the
+// only field that can legitimately contain arbitrary text.
+const sessionInfoFormat = "#{session_attached}\t#{session_windows}\t#{session_created}\t#{session_activity}\t#{session_path}\t#{session_name}"
+
+// ListSessionInfo returns every session on the tmux server, annotated with
+// the group and key of the mapping rooted at its path. A server that is not
+// running yields no sessions.
+func (m *Manager) ListSessionInfo() ([]SessionInfo, error) {
+	output, err := tmuxCommand("list-sessions", "-F", sessionInfoFormat).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if strings.Contains(msg, "no server running") || strings.Contains(msg, "error connecting") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list sessions: %s", msg)
+	}
+
+	var sessions []SessionInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		info, ok := parseSessionInfo(line)
+		if !ok {
+			continue
+		}
+		if group, key, found := m.FindMappingForPath(info.Path); found {
+			info.Group = group
+			info.Key = key
+		}
+		sessions = append(sessions, info)
+	}
+	return sessions, nil
+}
+
+// RunningSessionPaths maps every running session to its root directory.
+// It returns nil when the paths cannot be determined, which disables
+// session name collision handling.
+func RunningSessionPaths() map[string]string {
+	if mux.ActiveMux() == mux.MuxTuimux {
+		ctx := context.Background()
+		engine, err := mux.DetectMuxEngine(ctx)
+		if err != nil {
+			return nil
+		}
+		paths, err := EngineSessionPaths(ctx, engine)
+		if err != nil {
+			return nil
+		}
+		return paths
+	}
+	output, err := tmuxCommand("list-sessions", "-F", "#{session_path}\t#{session_name}").CombinedOutput()
+	if err != nil {
+		msg := string(output)
+		if strings.Contains(msg, "no server running") || strings.Contains(msg, "error connecting") {
+			return map[string]string{}
+		}
+		return nil
+	}
+	paths := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		path, name, ok := strings.Cut(line, "\t")
+		if ok {
+			paths[name] = path
+		}
+	}
+	return paths
+}
+
+// EngineSessionPaths maps every session engine reports to its root
+// directory, for multiplexers that have no list-sessions format to read
+// them all at once. Sessions whose path cannot be read are left out.
+func EngineSessionPaths(ctx context.Context, engine mux.MuxEngine) (map[string]string, error) {
+	sessions, err := engine.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string]string, len(sessions))
+	for _, s := range sessions {
+		if path, err := engine.GetSessionPath(ctx, s.Name); err == nil && path != "" {
+			paths[s.Name] = path
+		}
+	}
+	return paths, nil
+}
+
+// GetSessionNameTemplate returns the configured session_name_template.
+func (m *Manager) GetSessionNameTemplate() string {
+	if m.tmuxConfig == nil {
+		return ""
+	}
+	return m.tmuxConfig.SessionNameTemplate
+}
+
+// SessionName returns the session name for a project: the configured
+// template rendered for node, with a collision suffix when another known
+// project renders to the same name (see api.SessionNamer) or a session
+// rooted elsewhere already uses it.
+func (m *Manager) SessionName(node *workspace.WorkspaceNode) string {
+	namer := api.SessionNamer{Template: m.GetSessionNameTemplate(), Running: RunningSessionPaths()}
+	if projects := m.KnownProjectNodes(); projects != nil {
+		namer = namer.WithProjects(projects)
+	}
+	return namer.Name(node)
+}
+
+// KnownProjectNodes returns the discovered projects, loaded once per
+// Manager. It returns nil when discovery fails, which leaves session names
+// to be resolved against the running sessions only.
+func (m *Manager) KnownProjectNodes() []*workspace.WorkspaceNode {
+	if m.projectNodes == nil {
+		projects, err := m.GetAvailableProjects()
+		if err != nil {
+			return nil
+		}
+		nodes := make([]*workspace.WorkspaceNode, 0, len(projects))
+		for _, p := range projects {
+			nodes = append(nodes, p.WorkspaceNode)
+		}
+		m.projectNodes = nodes
+	}
+	return m.projectNodes
+}
+
+// parseSessionInfo parses one line of sessionInfoFormat output.
+func parseSessionInfo(line string) (SessionInfo, bool) {
+	parts := strings.SplitN(line, "\t", 6)
+	if len(parts) < 6 {
+		return SessionInfo{}, false
+	}
+	attached, _ := strconv.Atoi(parts[0])
+	windows, _ := strconv.Atoi(parts[1])
+	return SessionInfo{
+		Name:         parts[5],
+		Path:         parts[4],
+		Attached:     attached,
+		Windows:      windows,
+		Created:      parseUnixTime(parts[2]),
+		LastActivity: parseUnixTime(parts[3]),
+	}, true
+}
+
+// parseUnixTime converts a tmux epoch-seconds field to a time. Unparseable
+// values yield the zero time.
+func parseUnixTime(s string) time.Time {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || secs == 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
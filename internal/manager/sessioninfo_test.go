@@ -0,0 +1,26 @@
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSessionInfo(t *testing.T) {
+	info, ok := parseSessionInfo("2\t3\t1700000000\t1700000600\t/work/api\tapi\twith tab")
+	if !ok {
+		t.Fatal("parseSessionInfo() rejected a valid line")
+	}
+	if info.Name != "api\twith tab" {
+		t.Errorf("Name = %q, want the remainder of the line", info.Name)
+	}
+	if info.Path != "/work/api" || info.Attached != 2 || info.Windows != 3 {
+		t.Errorf("parseSessionInfo() = %+v", info)
+	}
+	if !info.Created.Equal(time.Unix(1700000000, 0)) || !info.LastActivity.Equal(time.Unix(1700000600, 0)) {
+		t.Errorf("timestamps = %v / %v", info.Created, info.LastActivity)
+	}
+
+	if _, ok := parseSessionInfo("not enough fields"); ok {
+		t.Error("parseSessionInfo() accepted a malformed line")
+	}
+}
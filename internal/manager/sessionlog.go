@@ -0,0 +1,147 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// Session end events recorded by `nav record-session --event`.
+const (
+	SessionEventClosed   = "closed"
+	SessionEventDetached = "detached"
+)
+
+// SessionEnd is when a project's session was last closed, or last left by
+// a detaching client.
+type SessionEnd struct {
+	Path    string    `json:"path"`
+	Session string    `json:"session"`
+	Event   string    `json:"event"`
+	Ended   time.Time `json:"ended"`
+}
+
+// sessionLog complements the access history with what the tmux hooks
+// report: which path each session name was last seen at, since a closed
+// session can no longer be asked for its path, and when each project's
+// session last ended.
+type sessionLog struct {
+	Sessions map[string]string     `json:"sessions"` // session name -> path
+	Ends     map[string]SessionEnd `json:"ends"`     // path -> last end
+}
+
+// SessionLogPath is where the session log is stored.
+func SessionLogPath() string {
+	return filepath.Join(paths.StateDir(), "nav", "session-log.json")
+}
+
+// RecordSessionSwitch notes that the session name is rooted at path, so
+// its end can be attributed to the project once the session is gone.
+func (m *Manager) RecordSessionSwitch(name, path string) error {
+	return updateSessionLog(SessionLogPath(), func(l *sessionLog) bool {
+		return l.recordSwitch(name, path)
+	})
+}
+
+// RecordSessionEnd records that the session name ended with event at the
+// given time. path may be empty for a session that no longer exists; it is
+// then looked up from the last switch into the session. Sessions nav never
+// saw are ignored.
+func (m *Manager) RecordSessionEnd(name, path, event string, at time.Time) error {
+	return updateSessionLog(SessionLogPath(), func(l *sessionLog) bool {
+		return l.recordEnd(name, path, event, at)
+	})
+}
+
+func (l *sessionLog) recordSwitch(name, path string) bool {
+	if name == "" || path == "" || l.Sessions[name] == path {
+		return false
+	}
+	l.Sessions[name] = path
+	return true
+}
+
+func (l *sessionLog) recordEnd(name, path, event string, at time.Time) bool {
+	if path == "" {
+		path = l.Sessions[name]
+	}
+	if path == "" {
+		return false
+	}
+	l.Ends[path] = SessionEnd{Path: path, Session: name, Event: event, Ended: at}
+	if event == SessionEventClosed {
+		// The name is free again and may next be used for another path.
+		delete(l.Sessions, name)
+	}
+	return true
+}
+
+// loadSessionLog reads the log at path; a missing file is an empty log.
+func loadSessionLog(path string) (*sessionLog, error) {
+	l := &sessionLog{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, l); err != nil {
+			return nil, fmt.Errorf("failed to parse session log: %w", err)
+		}
+	}
+	if l.Sessions == nil {
+		l.Sessions = make(map[string]string)
+	}
+	if l.Ends == nil {
+		l.Ends = make(map[string]SessionEnd)
+	}
+	return l, nil
+}
+
+// updateSessionLog applies update to the log at path and writes it back
+// when update reports a change. Hooks for several clients can fire at
+// once, so the whole read-modify-write holds an exclusive lock on a
+// sibling lock file, and the log itself is replaced atomically.
+func updateSessionLog(path string, update func(*sessionLog) bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create nav state directory: %w", err)
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to lock session log: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock session log: %w", err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	l, err := loadSessionLog(path)
+	if err != nil {
+		return err
+	}
+	if !update(l) {
+		return nil
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session log: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".session-log-*")
+	if err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
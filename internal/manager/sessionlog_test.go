@@ -0,0 +1,84 @@
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "nav", "session-log.json")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	update := func(f func(*sessionLog) bool) {
+		t.Helper()
+		if err := updateSessionLog(logPath, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	update(func(l *sessionLog) bool { return l.recordSwitch("api", "/src/api") })
+	update(func(l *sessionLog) bool { return l.recordSwitch("web", "/src/web") })
+
+	// A detached client's session still exists, so its path is passed in.
+	update(func(l *sessionLog) bool { return l.recordEnd("web", "/src/web", SessionEventDetached, at) })
+	// A closed session is resolved from the last switch into it.
+	update(func(l *sessionLog) bool { return l.recordEnd("api", "", SessionEventClosed, at.Add(time.Hour)) })
+	// Sessions nav never switched into are ignored.
+	update(func(l *sessionLog) bool { return l.recordEnd("scratch", "", SessionEventClosed, at) })
+
+	l, err := loadSessionLog(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Ends["/src/api"]; got.Event != SessionEventClosed || !got.Ended.Equal(at.Add(time.Hour)) || got.Session != "api" {
+		t.Errorf("api end = %+v", got)
+	}
+	if got := l.Ends["/src/web"]; got.Event != SessionEventDetached || !got.Ended.Equal(at) {
+		t.Errorf("web end = %+v", got)
+	}
+	if len(l.Ends) != 2 {
+		t.Errorf("expected 2 ends, got %+v", l.Ends)
+	}
+	if _, ok := l.Sessions["api"]; ok {
+		t.Error("a closed session's name should be forgotten")
+	}
+	if l.Sessions["web"] != "/src/web" {
+		t.Error("a detached session's name should be kept")
+	}
+}
+
+func TestSessionLogConcurrentUpdates(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "nav", "session-log.json")
+
+	// Hooks for several clients fire at once; no update may be lost.
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("s%d", i)
+			errs <- updateSessionLog(logPath, func(l *sessionLog) bool {
+				return l.recordSwitch(name, "/src/"+name)
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, err := loadSessionLog(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Sessions) != n {
+		t.Errorf("expected %d sessions, got %d: %v", n, len(l.Sessions), l.Sessions)
+	}
+}
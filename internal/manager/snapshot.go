@@ -0,0 +1,299 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	tmuxclient "github.com/grovetools/core/pkg/tmux"
+	"gopkg.in/yaml.v3"
+)
+
+// navSessionOption is the tmux user option nav sets on sessions it creates,
+// so `nav session save` can tell them apart from sessions made by hand.
+const navSessionOption = "@nav_managed"
+
+// SessionSnapshotFile is the on-disk record written by `nav session save`.
+type SessionSnapshotFile struct {
+	SavedAt  time.Time         `yaml:"saved_at"`
+	Sessions []SessionSnapshot `yaml:"sessions"`
+}
+
+// SessionSnapshot records enough of a session to rebuild it after the tmux
+// server has gone away.
+type SessionSnapshot struct {
+	Name    string           `yaml:"name"`
+	Path    string           `yaml:"path,omitempty"`
+	Managed bool             `yaml:"managed,omitempty"` // carried the nav marker option
+	Windows []WindowSnapshot `yaml:"windows"`
+}
+
+// WindowSnapshot records a window and its tmux layout string.
+type WindowSnapshot struct {
+	Index  int            `yaml:"index"`
+	Name   string         `yaml:"name,omitempty"`
+	Layout string         `yaml:"layout,omitempty"`
+	Active bool           `yaml:"active,omitempty"`
+	Panes  []PaneSnapshot `yaml:"panes"`
+}
+
+// PaneSnapshot records a pane's working directory and the full command
+// line of its foreground process.
+type PaneSnapshot struct {
+	Dir     string `yaml:"dir,omitempty"`
+	Command string `yaml:"command,omitempty"`
+	Active  bool   `yaml:"active,omitempty"`
+}
+
+// WindowLister enumerates the windows of a session. The windows TUI driver
+// satisfies it, so save reads windows through the same surface the
+// windows browser uses.
+type WindowLister interface {
+	ListWindows(ctx context.Context, sessionName string) ([]tmuxclient.Window, error)
+}
+
+// shellCommands are foreground processes that mean "idle prompt". Restore
+// does not re-run them, since the pane already starts a shell.
+var shellCommands = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true,
+	"ksh": true, "tcsh": true, "nu": true, "login": true,
+}
+
+// IsShellCommand reports whether command is an interactive shell.
+func IsShellCommand(command string) bool {
+	return shellCommands[strings.TrimPrefix(filepath.Base(command), "-")]
+}
+
+// SnapshotPath returns the location of the session snapshot file in the
+// nav state directory.
+func SnapshotPath() string {
+	return filepath.Join(paths.StateDir(), "nav", "session-snapshot.yml")
+}
+
+// LoadSnapshot reads the saved session snapshot. A missing file yields an
+// empty snapshot.
+func LoadSnapshot() (*SessionSnapshotFile, error) {
+	data, err := os.ReadFile(SnapshotPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SessionSnapshotFile{}, nil
+		}
+		return nil, err
+	}
+	var snap SessionSnapshotFile
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse session snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// Save writes the snapshot atomically, so an autosave interrupted by a
+// dying server never leaves a truncated file behind.
+func (f *SessionSnapshotFile) Save() error {
+	path := SnapshotPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".session-snapshot-*.yml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// IsNavSession reports whether nav created the session (it carries the
+// nav marker option) or the session's root is a mapped project.
+func (m *Manager) IsNavSession(sessionName string) bool {
+	if out, err := tmuxOutput("show-options", "-qv", "-t", sessionName, navSessionOption); err == nil && out == "1" {
+		return true
+	}
+	path, err := tmuxOutput("display-message", "-p", "-t", sessionName, "#{session_path}")
+	if err != nil || path == "" {
+		return false
+	}
+	_, _, ok := m.FindMappingForPath(path)
+	return ok
+}
+
+// CaptureSession records the windows, layout, pane directories and
+// foreground commands of a running session.
+func CaptureSession(ctx context.Context, lister WindowLister, sessionName string) (SessionSnapshot, error) {
+	snap := SessionSnapshot{Name: sessionName}
+	if path, err := tmuxOutput("display-message", "-p", "-t", sessionName, "#{session_path}"); err == nil {
+		snap.Path = path
+	}
+	if out, err := tmuxOutput("show-options", "-qv", "-t", sessionName, navSessionOption); err == nil && out == "1" {
+		snap.Managed = true
+	}
+
+	windows, err := lister.ListWindows(ctx, sessionName)
+	if err != nil {
+		return snap, fmt.Errorf("failed to list windows for %s: %w", sessionName, err)
+	}
+
+	for _, w := range windows {
+		ws := WindowSnapshot{Index: w.Index, Name: w.Name, Active: w.IsActive}
+		if layout, err := tmuxOutput("display-message", "-p", "-t", w.ID, "#{window_layout}"); err == nil {
+			ws.Layout = layout
+		}
+		out, err := tmuxOutput("list-panes", "-t", w.ID, "-F", "#{?pane_active,1,0}\t#{pane_current_command}\t#{pane_pid}\t#{pane_current_path}")
+		if err != nil {
+			return snap, fmt.Errorf("failed to list panes for %s:%d: %w", sessionName, w.Index, err)
+		}
+		for _, line := range strings.Split(out, "\n") {
+			parts := strings.SplitN(line, "\t", 4)
+			if len(parts) < 4 {
+				continue
+			}
+			pane := PaneSnapshot{Active: parts[0] == "1", Dir: parts[3]}
+			if !IsShellCommand(parts[1]) {
+				// pane_current_command is only the process name; restore
+				// needs the arguments too.
+				pane.Command = parts[1]
+				if args, err := foregroundCommand(parts[2]); err == nil && args != "" {
+					pane.Command = args
+				}
+			}
+			ws.Panes = append(ws.Panes, pane)
+		}
+		snap.Windows = append(snap.Windows, ws)
+	}
+	return snap, nil
+}
+
+// foregroundCommand returns the command line of the foreground process
+// group leader on the terminal of the pane whose first process is
+// panePID. Arguments are joined with spaces, as ps prints them.
+func foregroundCommand(panePID string) (string, error) {
+	pgid, err := psField(panePID, "tpgid")
+	if err != nil {
+		return "", err
+	}
+	return psField(pgid, "args")
+}
+
+// psField returns a single ps output field for pid.
+func psField(pid, field string) (string, error) {
+	out, err := exec.Command("ps", "-o", field+"=", "-p", pid).Output()
+	if err != nil {
+		return "", fmt.Errorf("ps %s for %s: %w", field, pid, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RestoreSession re-creates a saved session detached. Callers skip
+// sessions that already exist, which keeps restore idempotent. A session
+// that fails partway through is killed again.
+func RestoreSession(snap SessionSnapshot) (err error) {
+	if len(snap.Windows) == 0 {
+		return fmt.Errorf("session %s has no windows recorded", snap.Name)
+	}
+	created := false
+	defer func() {
+		if err != nil && created {
+			killSession(snap.Name)
+		}
+	}()
+
+	var activeWindow string
+	for wi, w := range snap.Windows {
+		firstDir := snap.Path
+		if len(w.Panes) > 0 && w.Panes[0].Dir != "" {
+			firstDir = w.Panes[0].Dir
+		}
+		firstDir = existingDir(firstDir)
+
+		var args []string
+		if wi == 0 {
+			args = []string{"new-session", "-d", "-s", snap.Name, "-P", "-F", "#{window_id}"}
+		} else {
+			args = []string{"new-window", "-d", "-t", snap.Name + ":", "-P", "-F", "#{window_id}"}
+		}
+		if firstDir != "" {
+			args = append(args, "-c", firstDir)
+		}
+		if w.Name != "" {
+			args = append(args, "-n", w.Name)
+		}
+		windowID, err := tmuxOutput(args...)
+		if err != nil {
+			return fmt.Errorf("failed to restore window %d of %s: %w", w.Index, snap.Name, err)
+		}
+		if wi == 0 {
+			created = true
+			if snap.Managed {
+				_ = tmuxCommand("set-option", "-t", snap.Name, navSessionOption, "1").Run()
+			}
+		}
+
+		paneIDs := []string{}
+		if first, err := tmuxOutput("display-message", "-p", "-t", windowID, "#{pane_id}"); err == nil {
+			paneIDs = append(paneIDs, first)
+		}
+		for pi := 1; pi < len(w.Panes); pi++ {
+			splitArgs := []string{"split-window", "-d", "-t", windowID, "-P", "-F", "#{pane_id}"}
+			if dir := existingDir(w.Panes[pi].Dir); dir != "" {
+				splitArgs = append(splitArgs, "-c", dir)
+			}
+			paneID, err := tmuxOutput(splitArgs...)
+			if err != nil {
+				return fmt.Errorf("failed to restore pane %d of %s:%d: %w", pi, snap.Name, w.Index, err)
+			}
+			paneIDs = append(paneIDs, paneID)
+			// Keep room for the next split; the saved layout is applied below.
+			_ = tmuxCommand("select-layout", "-t", windowID, "tiled").Run()
+		}
+
+		if w.Layout != "" {
+			_ = tmuxCommand("select-layout", "-t", windowID, w.Layout).Run()
+		}
+		for pi, paneID := range paneIDs {
+			if pi >= len(w.Panes) {
+				break
+			}
+			if cmd := w.Panes[pi].Command; cmd != "" {
+				_ = tmuxCommand("send-keys", "-t", paneID, cmd, "Enter").Run()
+			}
+			if w.Panes[pi].Active {
+				_ = tmuxCommand("select-pane", "-t", paneID).Run()
+			}
+		}
+		if w.Active {
+			activeWindow = windowID
+		}
+	}
+
+	if activeWindow != "" {
+		_ = tmuxCommand("select-window", "-t", activeWindow).Run()
+	}
+	return nil
+}
+
+// existingDir returns dir when it still exists on disk, otherwise "" so
+// tmux falls back to its default directory instead of failing.
+func existingDir(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/mux"
+	tmuxclient "github.com/grovetools/core/pkg/tmux"
+)
+
+func TestIsShellCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"zsh", true},
+		{"-bash", true},
+		{"/usr/local/bin/fish", true},
+		{"nvim", false},
+		{"node", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsShellCommand(tt.command); got != tt.want {
+			t.Errorf("IsShellCommand(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestSnapshotSaveLoadRoundTrip(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+
+	empty, err := LoadSnapshot()
+	if err != nil {
+		t.Fatalf("LoadSnapshot() on missing file error = %v", err)
+	}
+	if len(empty.Sessions) != 0 {
+		t.Fatalf("LoadSnapshot() on missing file = %+v, want empty", empty)
+	}
+
+	want := &SessionSnapshotFile{
+		SavedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Sessions: []SessionSnapshot{{
+			Name: "api",
+			Path: "/work/api",
+			Windows: []WindowSnapshot{{
+				Index:  1,
+				Name:   "dev",
+				Layout: "b25d,200x50,0,0{100x50,0,0,1,99x50,101,0,2}",
+				Active: true,
+				Panes: []PaneSnapshot{
+					{Dir: "/work/api", Command: "nvim", Active: true},
+					{Dir: "/work/api/web"},
+				},
+			}},
+		}},
+	}
+	if err := want.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := LoadSnapshot()
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadSnapshot() = %+v, want %+v", got, want)
+	}
+}
+
+// tmuxWindowLister lists windows with tmux list-windows.
+type tmuxWindowLister struct{}
+
+func (tmuxWindowLister) ListWindows(ctx context.Context, sessionName string) ([]tmuxclient.Window, error) {
+	out, err := tmuxOutput("list-windows", "-t", sessionName, "-F", "#{window_id}\t#{window_index}\t#{window_name}\t#{window_active}")
+	if err != nil {
+		return nil, err
+	}
+	var windows []tmuxclient.Window
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Split(line, "\t")
+		index, _ := strconv.Atoi(parts[1])
+		windows = append(windows, tmuxclient.Window{ID: parts[0], Index: index, Name: parts[2], IsActive: parts[3] == "1"})
+	}
+	return windows, nil
+}
+
+// startTestServer points tmuxCommand at a private tmux server for the test.
+func startTestServer(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+	t.Setenv(mux.EnvGroveTmuxSocket, fmt.Sprintf("nav-%s-%d", t.Name(), os.Getpid()))
+	t.Cleanup(func() { _ = tmuxCommand("kill-server").Run() })
+}
+
+func TestCaptureSessionRecordsCommandArguments(t *testing.T) {
+	startTestServer(t)
+
+	if out, err := tmuxCommand("new-session", "-d", "-s", "server", "sleep 300").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %s", out)
+	}
+	// The pane's shell execs the command; wait until it has.
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if name, _ := tmuxOutput("display-message", "-p", "-t", "server", "#{pane_current_command}"); name == "sleep" {
+			break
+		}
+	}
+
+	snap, err := CaptureSession(context.Background(), tmuxWindowLister{}, "server")
+	if err != nil {
+		t.Fatalf("CaptureSession() error = %v", err)
+	}
+	if len(snap.Windows) != 1 || len(snap.Windows[0].Panes) != 1 {
+		t.Fatalf("CaptureSession() = %+v, want one window with one pane", snap)
+	}
+	if got := snap.Windows[0].Panes[0].Command; got != "sleep 300" {
+		t.Errorf("pane command = %q, want the full command line %q", got, "sleep 300")
+	}
+}
+
+func TestRestoreSessionMarksOnlyManagedSessions(t *testing.T) {
+	startTestServer(t)
+
+	window := []WindowSnapshot{{Panes: []PaneSnapshot{{}}}}
+	for _, snap := range []SessionSnapshot{
+		{Name: "managed", Managed: true, Windows: window},
+		{Name: "manual", Windows: window},
+	} {
+		if err := RestoreSession(snap); err != nil {
+			t.Fatalf("RestoreSession(%s) error = %v", snap.Name, err)
+		}
+		marker, _ := tmuxOutput("show-options", "-qv", "-t", snap.Name, navSessionOption)
+		if got := marker == "1"; got != snap.Managed {
+			t.Errorf("%s: marked = %v, want %v", snap.Name, got, snap.Managed)
+		}
+	}
+}
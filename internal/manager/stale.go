@@ -0,0 +1,181 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/grovetools/core/pkg/paths"
+
+	navbindings "github.com/grovetools/nav/pkg/bindings"
+)
+
+// StaleMapping is a key mapped to a path that no longer exists, such as a
+// deleted worktree or a moved repository.
+type StaleMapping struct {
+	Group  string `json:"group"`
+	Key    string `json:"key"`
+	Path   string `json:"path"`
+	Locked bool   `json:"locked,omitempty"`
+}
+
+// PruneUndoPath is where PruneStaleMappings records what it unmapped, so
+// `nav key prune --undo` can restore it from a later process.
+func PruneUndoPath() string {
+	return filepath.Join(paths.StateDir(), "nav", "prune-undo.json")
+}
+
+// FindStaleMappings returns the active group's mappings whose path no
+// longer exists, or every group's when allGroups is set.
+func (m *Manager) FindStaleMappings(allGroups bool) []StaleMapping {
+	groups := []string{m.GetActiveGroup()}
+	if allGroups {
+		groups = m.GetAllGroups()
+	}
+
+	originalGroup := m.activeGroup
+	defer m.SetActiveGroup(originalGroup)
+
+	locked := make(map[string]bool, len(m.lockedKeys))
+	for _, k := range m.lockedKeys {
+		locked[k] = true
+	}
+
+	var stale []StaleMapping
+	for _, group := range groups {
+		m.SetActiveGroup(group)
+		stale = append(stale, staleMappings(group, m.sessions, locked)...)
+	}
+	return stale
+}
+
+// staleMappings returns the mappings in sessions whose path is missing,
+// sorted by key.
+func staleMappings(group string, sessions map[string]TmuxSessionConfig, locked map[string]bool) []StaleMapping {
+	var stale []StaleMapping
+	for key, sess := range sessions {
+		if sess.Path == "" || !navbindings.MissingPath(sess.Path) {
+			continue
+		}
+		stale = append(stale, StaleMapping{Group: group, Key: key, Path: sess.Path, Locked: locked[key]})
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Key < stale[j].Key })
+	return stale
+}
+
+// PruneStaleMappings unmaps the given stale mappings, keeping locked keys,
+// and returns the ones it removed. The previous state is pushed onto the
+// undo stack and the removed mappings are written to PruneUndoPath.
+// Bindings are not regenerated.
+func (m *Manager) PruneStaleMappings(stale []StaleMapping) ([]StaleMapping, error) {
+	var prune []StaleMapping
+	for _, s := range stale {
+		if !s.Locked {
+			prune = append(prune, s)
+		}
+	}
+	if len(prune) == 0 {
+		return nil, nil
+	}
+
+	m.TakeSnapshot()
+	if err := writePruneUndo(prune); err != nil {
+		return nil, err
+	}
+
+	originalGroup := m.activeGroup
+	defer m.SetActiveGroup(originalGroup)
+
+	for _, group := range mappingGroups(prune) {
+		m.SetActiveGroup(group)
+		for _, s := range prune {
+			if s.Group == group {
+				delete(m.sessions, s.Key)
+			}
+		}
+		if err := m.Save(); err != nil {
+			return nil, fmt.Errorf("failed to save group %s: %w", group, err)
+		}
+	}
+	return prune, nil
+}
+
+// UndoPrune restores the mappings removed by the last prune. Keys that
+// have been mapped again since are left alone and returned as skipped.
+func (m *Manager) UndoPrune() (restored, skipped []StaleMapping, err error) {
+	data, err := os.ReadFile(PruneUndoPath())
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("no prune to undo")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read prune undo file: %w", err)
+	}
+	var pruned []StaleMapping
+	if err := json.Unmarshal(data, &pruned); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse prune undo file: %w", err)
+	}
+
+	m.TakeSnapshot()
+	originalGroup := m.activeGroup
+	defer m.SetActiveGroup(originalGroup)
+
+	for _, group := range mappingGroups(pruned) {
+		m.SetActiveGroup(group)
+		if m.sessions == nil {
+			m.sessions = make(map[string]TmuxSessionConfig)
+		}
+		changed := false
+		for _, s := range pruned {
+			if s.Group != group {
+				continue
+			}
+			if m.sessions[s.Key].Path != "" {
+				skipped = append(skipped, s)
+				continue
+			}
+			m.sessions[s.Key] = TmuxSessionConfig{Path: s.Path}
+			restored = append(restored, s)
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if err := m.Save(); err != nil {
+			return nil, nil, fmt.Errorf("failed to save group %s: %w", group, err)
+		}
+	}
+
+	if err := os.Remove(PruneUndoPath()); err != nil {
+		return nil, nil, fmt.Errorf("failed to remove prune undo file: %w", err)
+	}
+	return restored, skipped, nil
+}
+
+// writePruneUndo records pruned mappings for UndoPrune.
+func writePruneUndo(pruned []StaleMapping) error {
+	data, err := json.MarshalIndent(pruned, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal prune undo file: %w", err)
+	}
+	path := PruneUndoPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create nav state directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// mappingGroups returns the distinct groups of mappings in first-seen
+// order.
+func mappingGroups(mappings []StaleMapping) []string {
+	seen := make(map[string]bool)
+	var groups []string
+	for _, s := range mappings {
+		if !seen[s.Group] {
+			seen[s.Group] = true
+			groups = append(groups, s.Group)
+		}
+	}
+	return groups
+}
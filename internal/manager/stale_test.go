@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStaleMappings(t *testing.T) {
+	dir := t.TempDir()
+	gone := filepath.Join(dir, "deleted-worktree")
+	sessions := map[string]TmuxSessionConfig{
+		"a": {Path: dir},
+		"b": {Path: gone},
+		"c": {Path: filepath.Join(dir, "moved")},
+		"d": {},
+	}
+	got := staleMappings("work", sessions, map[string]bool{"c": true})
+	want := []StaleMapping{
+		{Group: "work", Key: "b", Path: gone},
+		{Group: "work", Key: "c", Path: filepath.Join(dir, "moved"), Locked: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("staleMappings() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMappingGroups(t *testing.T) {
+	got := mappingGroups([]StaleMapping{{Group: "work", Key: "a"}, {Group: "default", Key: "b"}, {Group: "work", Key: "c"}})
+	if want := []string{"work", "default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mappingGroups() = %v, want %v", got, want)
+	}
+}
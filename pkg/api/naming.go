@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// SessionNamer turns workspace nodes into mux session names. Every code
+// path that creates or looks up a project session (sessionize, nav start,
+// nav session new, the TUIs, and the mux drivers) goes through it so that
+// they all agree on the name.
+//
+// Template is the configured session_name_template. It may reference
+// {name}, {repo}, {worktree}, {ecosystem} and {identifier}; an empty
+// template keeps the historical Identifier("_") naming. Placeholders that
+// resolve to nothing drop the separator next to them, so
+// "{ecosystem}/{repo}@{worktree}" renders a standalone repo as just "repo".
+//
+// Collisions between known projects (see WithProjects) are settled by
+// their paths alone: the project whose path sorts first keeps the plain
+// name and the others get a deterministic suffix derived from their path.
+// The names are then the same whichever project starts first, and across
+// restarts.
+//
+// Running maps running session names to their root directories. A
+// project whose name is taken by a running session that is not one of the
+// colliding projects (a session the user started by hand, say) is
+// suffixed as well. Without known projects, collisions are resolved
+// against the running sessions only, and which project gets the suffix
+// depends on start order. A nil map and no known projects disable
+// collision handling.
+type SessionNamer struct {
+	Template string
+	Running  map[string]string
+
+	// claims maps each base name to the cleaned paths of the known
+	// projects rendering to it, sorted.
+	claims map[string][]string
+}
+
+// WithProjects returns a copy of n that resolves collisions among the
+// given known projects.
+func (n SessionNamer) WithProjects(projects []*workspace.WorkspaceNode) SessionNamer {
+	n.claims = make(map[string][]string)
+	for _, p := range projects {
+		if p == nil {
+			continue
+		}
+		base := n.BaseName(p)
+		n.claims[base] = append(n.claims[base], filepath.Clean(p.Path))
+	}
+	for _, paths := range n.claims {
+		slices.Sort(paths)
+	}
+	return n
+}
+
+// SessionPathLister is implemented by session drivers that can report the
+// root directory of every running session. Callers use it to fill
+// SessionNamer.Running; drivers without it get no collision handling.
+type SessionPathLister interface {
+	ListActivePaths(ctx context.Context) (map[string]string, error)
+}
+
+// Name returns the session name for node, resolving collisions against
+// the known projects and the running sessions.
+func (n SessionNamer) Name(node *workspace.WorkspaceNode) string {
+	base := n.BaseName(node)
+	if node == nil {
+		return base
+	}
+	suffixed := base + "-" + CollisionSuffix(node.Path)
+
+	if n.claims != nil {
+		path := filepath.Clean(node.Path)
+		claims := n.claims[base]
+		// Only the first known project keeps the name; an unknown one
+		// colliding with a known one yields to it.
+		if len(claims) > 0 && claims[0] != path {
+			return suffixed
+		}
+		if root, ok := n.Running[base]; ok && root != "" && filepath.Clean(root) != path && !slices.Contains(claims, filepath.Clean(root)) {
+			return suffixed
+		}
+		return base
+	}
+
+	if n.Running == nil {
+		return base
+	}
+	if root, ok := n.Running[suffixed]; ok && samePath(root, node.Path) {
+		return suffixed
+	}
+	if root, ok := n.Running[base]; ok && root != "" && !samePath(root, node.Path) {
+		return suffixed
+	}
+	return base
+}
+
+// BaseName renders the template for node without collision handling.
+func (n SessionNamer) BaseName(node *workspace.WorkspaceNode) string {
+	if node == nil {
+		return ""
+	}
+	if n.Template == "" {
+		return node.Identifier("_")
+	}
+
+	fields := sessionNameFields(node)
+	segments := parseNameTemplate(n.Template)
+	for i := range segments {
+		if segments[i].field {
+			segments[i].text = sanitizeSessionName(fields[segments[i].text])
+		}
+	}
+
+	// An empty placeholder drops the literal before it, or the literal
+	// after it when it leads the template.
+	for i, seg := range segments {
+		if !seg.field || seg.text != "" {
+			continue
+		}
+		switch {
+		case i > 0 && !segments[i-1].field:
+			segments[i-1].text = ""
+		case i+1 < len(segments) && !segments[i+1].field:
+			segments[i+1].text = ""
+		}
+	}
+
+	var b strings.Builder
+	for _, seg := range segments {
+		if seg.field {
+			b.WriteString(seg.text)
+		} else {
+			b.WriteString(sanitizeSessionName(seg.text))
+		}
+	}
+	name := b.String()
+	if name == "" {
+		return node.Identifier("_")
+	}
+	return name
+}
+
+// CollisionSuffix returns the short, path-derived suffix appended to a
+// session name that collides with a session rooted elsewhere.
+func CollisionSuffix(path string) string {
+	sum := sha1.Sum([]byte(filepath.Clean(path)))
+	return hex.EncodeToString(sum[:3])
+}
+
+// nameSegment is a literal or placeholder part of a session name template.
+type nameSegment struct {
+	text  string
+	field bool
+}
+
+// nameTemplateFields are the placeholders a template can reference.
+var nameTemplateFields = map[string]bool{
+	"name": true, "repo": true, "worktree": true, "ecosystem": true, "identifier": true,
+}
+
+// sessionNameFields resolves every template placeholder for node.
+func sessionNameFields(node *workspace.WorkspaceNode) map[string]string {
+	repo := node.Name
+	worktree := ""
+	if node.ParentProjectPath != "" {
+		repo = filepath.Base(node.ParentProjectPath)
+		worktree = node.Name
+	} else if node.ParentEcosystemPath != "" && node.RootEcosystemPath != "" && node.ParentEcosystemPath != node.RootEcosystemPath {
+		// A sub-project checked out inside an ecosystem worktree.
+		worktree = filepath.Base(node.ParentEcosystemPath)
+	}
+
+	ecosystemPath := node.RootEcosystemPath
+	if ecosystemPath == "" {
+		ecosystemPath = node.ParentEcosystemPath
+	}
+	ecosystem := ""
+	if ecosystemPath != "" && ecosystemPath != node.Path && ecosystemPath != node.ParentProjectPath {
+		ecosystem = filepath.Base(ecosystemPath)
+	}
+
+	return map[string]string{
+		"name":       node.Name,
+		"repo":       repo,
+		"worktree":   worktree,
+		"ecosystem":  ecosystem,
+		"identifier": node.Identifier("_"),
+	}
+}
+
+// parseNameTemplate splits a template into literals and {placeholders}.
+// Unknown placeholders are kept as literal text.
+func parseNameTemplate(template string) []nameSegment {
+	var segments []nameSegment
+	rest := template
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			segments = append(segments, nameSegment{text: rest})
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			segments = append(segments, nameSegment{text: rest})
+			break
+		}
+		end += open
+		name := rest[open+1 : end]
+		if !nameTemplateFields[name] {
+			segments = append(segments, nameSegment{text: rest[:end+1]})
+			rest = rest[end+1:]
+			continue
+		}
+		if open > 0 {
+			segments = append(segments, nameSegment{text: rest[:open]})
+		}
+		segments = append(segments, nameSegment{text: name, field: true})
+		rest = rest[end+1:]
+	}
+	return segments
+}
+
+// sanitizeSessionName replaces the characters tmux reserves in target
+// names ('.' and ':') with underscores.
+func sanitizeSessionName(s string) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(s)
+}
+
+// samePath reports whether two directory paths refer to the same place.
+func samePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
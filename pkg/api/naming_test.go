@@ -0,0 +1,123 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestSessionNamerBaseName(t *testing.T) {
+	standalone := &workspace.WorkspaceNode{Name: "grove.nvim", Path: "/src/grove.nvim", Kind: workspace.KindStandaloneProject}
+	worktree := &workspace.WorkspaceNode{
+		Name:              "feature",
+		Path:              "/src/api/.grove-worktrees/feature",
+		Kind:              workspace.KindStandaloneProjectWorktree,
+		ParentProjectPath: "/src/api",
+	}
+	subProject := &workspace.WorkspaceNode{
+		Name:                "core",
+		Path:                "/src/eco/core",
+		Kind:                workspace.KindEcosystemSubProject,
+		ParentEcosystemPath: "/src/eco",
+		RootEcosystemPath:   "/src/eco",
+	}
+	ecoWorktreeSub := &workspace.WorkspaceNode{
+		Name:                "core",
+		Path:                "/src/eco/.grove-worktrees/fix/core",
+		Kind:                workspace.KindEcosystemWorktreeSubProject,
+		ParentEcosystemPath: "/src/eco/.grove-worktrees/fix",
+		RootEcosystemPath:   "/src/eco",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		node     *workspace.WorkspaceNode
+		want     string
+	}{
+		{"empty template keeps identifier", "", worktree, worktree.Identifier("_")},
+		{"standalone drops empty fields", "{ecosystem}/{repo}@{worktree}", standalone, "grove_nvim"},
+		{"worktree", "{ecosystem}/{repo}@{worktree}", worktree, "api@feature"},
+		{"ecosystem sub-project", "{ecosystem}/{repo}@{worktree}", subProject, "eco/core"},
+		{"sub-project in ecosystem worktree", "{ecosystem}/{repo}@{worktree}", ecoWorktreeSub, "eco/core@fix"},
+		{"literal dots and colons are sanitized", "nav:{name}.x", subProject, "nav_core_x"},
+		{"unknown placeholders stay literal", "{repo}-{branch}", subProject, "core-{branch}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SessionNamer{Template: tt.template}.BaseName(tt.node)
+			if got != tt.want {
+				t.Errorf("BaseName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionNamerCollisions(t *testing.T) {
+	a := &workspace.WorkspaceNode{Name: "fix", Path: "/eco-a/api/fix", Kind: workspace.KindStandaloneProjectWorktree, ParentProjectPath: "/eco-a/api"}
+	b := &workspace.WorkspaceNode{Name: "fix", Path: "/eco-b/api/fix", Kind: workspace.KindStandaloneProjectWorktree, ParentProjectPath: "/eco-b/api"}
+	const template = "{repo}@{worktree}"
+	suffixB := "api@fix-" + CollisionSuffix(b.Path)
+
+	tests := []struct {
+		name    string
+		running map[string]string
+		node    *workspace.WorkspaceNode
+		want    string
+	}{
+		{"nothing running", map[string]string{}, b, "api@fix"},
+		{"own session running", map[string]string{"api@fix": a.Path}, a, "api@fix"},
+		{"taken by another path", map[string]string{"api@fix": a.Path}, b, suffixB},
+		{"suffixed session kept after the original closes", map[string]string{suffixB: b.Path}, b, suffixB},
+		{"collision handling disabled", nil, b, "api@fix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SessionNamer{Template: template, Running: tt.running}.Name(tt.node)
+			if got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if CollisionSuffix(b.Path) != CollisionSuffix(b.Path+"/") {
+		t.Error("CollisionSuffix should not depend on trailing slashes")
+	}
+}
+
+func TestSessionNamerKnownProjectsIgnoreStartOrder(t *testing.T) {
+	a := &workspace.WorkspaceNode{Name: "fix", Path: "/eco-a/api/fix", Kind: workspace.KindStandaloneProjectWorktree, ParentProjectPath: "/eco-a/api"}
+	b := &workspace.WorkspaceNode{Name: "fix", Path: "/eco-b/api/fix", Kind: workspace.KindStandaloneProjectWorktree, ParentProjectPath: "/eco-b/api"}
+	other := &workspace.WorkspaceNode{Name: "web", Path: "/src/web", Kind: workspace.KindStandaloneProject}
+	const template = "{repo}@{worktree}"
+	wantA, wantB := "api@fix", "api@fix-"+CollisionSuffix(b.Path)
+
+	for _, projects := range [][]*workspace.WorkspaceNode{{a, b, other}, {other, b, a}} {
+		// Start a then b, and b then a: each project is named with the
+		// other's session, if any, already running.
+		for _, order := range [][]*workspace.WorkspaceNode{{a, b}, {b, a}} {
+			running := map[string]string{}
+			got := map[string]string{}
+			for _, node := range order {
+				namer := SessionNamer{Template: template, Running: running}.WithProjects(projects)
+				name := namer.Name(node)
+				got[node.Path] = name
+				running[name] = node.Path
+			}
+			if got[a.Path] != wantA || got[b.Path] != wantB {
+				t.Errorf("started %s then %s: names %v, want %s and %s", order[0].Path, order[1].Path, got, wantA, wantB)
+			}
+		}
+	}
+
+	// A session started by hand under the plain name is not a project's,
+	// so even the first project is suffixed.
+	namer := SessionNamer{Template: template, Running: map[string]string{"api@fix": "/tmp"}}.WithProjects([]*workspace.WorkspaceNode{a, b})
+	if got, want := namer.Name(a), "api@fix-"+CollisionSuffix(a.Path); got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+	namer = SessionNamer{Template: template}.WithProjects([]*workspace.WorkspaceNode{a, other})
+	if got := namer.Name(a); got != wantA {
+		t.Errorf("Name() without a collision = %q, want %q", got, wantA)
+	}
+}
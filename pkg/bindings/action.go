@@ -0,0 +1,227 @@
+package bindings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ActionKind selects what a bound key does.
+type ActionKind string
+
+const (
+	// ActionSessionize switches to (or creates) the project's session.
+	ActionSessionize ActionKind = "sessionize"
+	// ActionWindow switches to the project's session and selects a window,
+	// creating it in the project directory if needed.
+	ActionWindow ActionKind = "window"
+	// ActionPopup runs a command in a popup in the project directory.
+	ActionPopup ActionKind = "popup"
+	// ActionTUI opens the nav TUI on a tab, optionally with a filter.
+	ActionTUI ActionKind = "tui"
+	// ActionNav runs a nav subcommand, e.g. "history last".
+	ActionNav ActionKind = "nav"
+)
+
+// tuiTabCommands maps the tabs a "tui" action can open to the nav command
+// that starts the TUI on that tab.
+var tuiTabCommands = map[string]string{
+	"sessionize": "sessionize",
+	"keys":       "km",
+	"history":    "history",
+	"windows":    "windows",
+	"groups":     "groups",
+}
+
+// Action is what a bound key does. The zero value sessionizes the bound
+// path, which is what every binding did before actions were configurable.
+type Action struct {
+	Kind    ActionKind
+	Window  string // window name or index, for ActionWindow
+	Command string // shell command for ActionPopup; nav arguments for ActionNav
+	Tab     string // tab for ActionTUI; defaults to "sessionize"
+	Filter  string // initial sessionizer filter for ActionTUI
+	Repeat  *bool  // bind with tmux's -r; defaults to true only for sessionize
+	// Windows maps keys typed after the binding to windows of the
+	// project session, for sessionize and window actions.
+	Windows map[string]string
+}
+
+// EffectiveKind returns the action kind, defaulting to sessionize.
+func (a Action) EffectiveKind() ActionKind {
+	if a.Kind == "" {
+		return ActionSessionize
+	}
+	return a.Kind
+}
+
+// Repeats reports whether the key is bound as repeatable (tmux -r), so it
+// can be pressed again without the prefix. Keys with window hotkeys default
+// to not repeating, since the next key picks a window.
+func (a Action) Repeats() bool {
+	if a.Repeat != nil {
+		return *a.Repeat
+	}
+	return a.EffectiveKind() == ActionSessionize && len(a.Windows) == 0
+}
+
+// WindowKeys returns the window hotkeys in key order.
+func (a Action) WindowKeys() []string {
+	keys := make([]string, 0, len(a.Windows))
+	for k := range a.Windows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WindowAction returns the action bound to the window hotkey key: select
+// that window in the project session.
+func (a Action) WindowAction(key string) Action {
+	return Action{Kind: ActionWindow, Window: a.Windows[key]}
+}
+
+// tab returns the TUI tab, defaulting to the sessionizer.
+func (a Action) tab() string {
+	if a.Tab == "" {
+		return "sessionize"
+	}
+	return a.Tab
+}
+
+// Validate checks that the action has the fields its kind needs.
+func (a Action) Validate() error {
+	switch a.EffectiveKind() {
+	case ActionSessionize:
+	case ActionWindow:
+		if a.Window == "" {
+			return fmt.Errorf("window action needs a window")
+		}
+	case ActionPopup:
+		if a.Command == "" {
+			return fmt.Errorf("popup action needs a command")
+		}
+	case ActionTUI:
+		if _, ok := tuiTabCommands[a.tab()]; !ok {
+			return fmt.Errorf("unknown tui tab %q (available: %s)", a.Tab, strings.Join(tuiTabs(), ", "))
+		}
+		if a.Filter != "" && a.tab() != "sessionize" {
+			return fmt.Errorf("tui filter is only supported on the sessionize tab")
+		}
+	case ActionNav:
+		if a.Command == "" {
+			return fmt.Errorf("nav action needs a command")
+		}
+	default:
+		return fmt.Errorf("unknown action %q (available: sessionize, window, popup, tui, nav)", a.Kind)
+	}
+	return a.validateWindows()
+}
+
+// validateWindows checks the window hotkeys: each is a single key tmux can
+// bind, naming a window, on an action that opens the project session.
+func (a Action) validateWindows() error {
+	if len(a.Windows) == 0 {
+		return nil
+	}
+	if kind := a.EffectiveKind(); kind != ActionSessionize && kind != ActionWindow {
+		return fmt.Errorf("window hotkeys are only supported on sessionize and window actions")
+	}
+	for _, key := range a.WindowKeys() {
+		switch {
+		case key == "":
+			return fmt.Errorf("window hotkey is empty")
+		case IsChord(key):
+			return fmt.Errorf("window hotkey %q must be a single key", key)
+		case !isTmuxKey(key):
+			return fmt.Errorf("window hotkey %q is not a key tmux can bind", key)
+		case a.Windows[key] == "":
+			return fmt.Errorf("window hotkey %q needs a window", key)
+		}
+	}
+	return nil
+}
+
+// Describe summarizes the action for listings such as `nav key list`.
+func (a Action) Describe() string {
+	var desc string
+	switch a.EffectiveKind() {
+	case ActionWindow:
+		desc = "window " + a.Window
+	case ActionPopup:
+		return "popup: " + a.Command
+	case ActionTUI:
+		if a.Filter != "" {
+			return fmt.Sprintf("tui %s (filter %q)", a.tab(), a.Filter)
+		}
+		return "tui " + a.tab()
+	case ActionNav:
+		return "nav " + a.Command
+	default:
+		desc = "sessionize"
+	}
+	if len(a.Windows) > 0 {
+		windows := make([]string, 0, len(a.Windows))
+		for _, key := range a.WindowKeys() {
+			windows = append(windows, key+"="+a.Windows[key])
+		}
+		desc += ", windows " + strings.Join(windows, " ")
+	}
+	return desc
+}
+
+// NavArgs returns the nav arguments that perform the action for path, or
+// nil for a popup, which runs the user's command rather than nav.
+func (a Action) NavArgs(path string) []string {
+	switch a.EffectiveKind() {
+	case ActionSessionize:
+		return []string{"sessionize", path}
+	case ActionWindow:
+		return []string{"sessionize", "--window", a.Window, path}
+	case ActionTUI:
+		args := []string{tuiTabCommands[a.tab()]}
+		if a.Filter != "" {
+			args = append(args, "--filter", a.Filter)
+		}
+		return args
+	case ActionNav:
+		return strings.Fields(a.Command)
+	}
+	return nil
+}
+
+// ShellCommand returns a POSIX shell command that performs the action for
+// path, with nav invoked as navCmd.
+func (a Action) ShellCommand(navCmd, path string) string {
+	switch a.EffectiveKind() {
+	case ActionPopup:
+		return "cd " + shellQuote(path) + " && " + a.Command
+	case ActionNav:
+		return navCmd + " " + a.Command
+	}
+	return navCmd + " " + shellArgs(a.NavArgs(path))
+}
+
+// ActionFor returns the action bound to key in the group.
+func (g GroupBinding) ActionFor(key string) Action {
+	return g.Actions[key]
+}
+
+// validateActions checks every bound key's action in the group.
+func validateActions(group GroupBinding) error {
+	for _, key := range sortedBoundKeys(group) {
+		if err := group.ActionFor(key).Validate(); err != nil {
+			return fmt.Errorf("group %q key %q: %w", group.Name, key, err)
+		}
+	}
+	return nil
+}
+
+func tuiTabs() []string {
+	tabs := make([]string, 0, len(tuiTabCommands))
+	for tab := range tuiTabCommands {
+		tabs = append(tabs, tab)
+	}
+	sort.Strings(tabs)
+	return tabs
+}
@@ -0,0 +1,153 @@
+package bindings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestActionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  Action
+		wantErr bool
+	}{
+		{"zero value sessionizes", Action{}, false},
+		{"window", Action{Kind: ActionWindow, Window: "editor"}, false},
+		{"window without name", Action{Kind: ActionWindow}, true},
+		{"popup without command", Action{Kind: ActionPopup}, true},
+		{"tui default tab", Action{Kind: ActionTUI, Filter: "api"}, false},
+		{"tui unknown tab", Action{Kind: ActionTUI, Tab: "plans"}, true},
+		{"tui filter on history", Action{Kind: ActionTUI, Tab: "history", Filter: "x"}, true},
+		{"nav", Action{Kind: ActionNav, Command: "history last"}, false},
+		{"unknown kind", Action{Kind: "open"}, true},
+		{"windows", Action{Windows: map[string]string{"e": "editor", "t": "tests"}}, false},
+		{"windows on popup", Action{Kind: ActionPopup, Command: "lazygit", Windows: map[string]string{"t": "tests"}}, true},
+		{"window hotkey chord", Action{Windows: map[string]string{"g t": "tests"}}, true},
+		{"window hotkey without window", Action{Windows: map[string]string{"t": ""}}, true},
+		{"window hotkey named key", Action{Windows: map[string]string{"C-t": "tests", "F5": "logs", ";": "shell"}}, false},
+		{"window hotkey space", Action{Windows: map[string]string{" ": "tests"}}, true},
+		{"window hotkey control character", Action{Windows: map[string]string{"\x1b": "tests"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.action.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActionBindings(t *testing.T) {
+	noRepeat := false
+	repeat := true
+	group := GroupBinding{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: "/src/api"},
+			"e": {Path: "/src/api"},
+			"g": {Path: "/src/api"},
+			"h": {Path: "/src/api"},
+			"t": {Path: "/src/api"},
+			"x": {Path: "/src/api"},
+		},
+		Actions: map[string]Action{
+			"a": {Repeat: &noRepeat},
+			"e": {Kind: ActionWindow, Window: "editor"},
+			"g": {Kind: ActionPopup, Command: "lazygit"},
+			"h": {Kind: ActionNav, Command: "history last", Repeat: &repeat},
+			"t": {Kind: ActionTUI, Filter: "api"},
+		},
+	}
+	opts := Options{BinDir: "/bin", CacheDir: "/cache"}
+
+	files, err := tmuxGenerator{}.Generate([]GroupBinding{group}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmuxConf := string(files[len(files)-1].Content)
+	for _, want := range []string{
+		`a run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize '/src/api'"`,
+		`-r -T nav-workspaces x run-shell`,
+		`e run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize --window 'editor' '/src/api'"`,
+		`g display-popup -E -d "/src/api" "lazygit"`,
+		`-r -T nav-workspaces h run-shell "HOME=$HOME PATH=$PATH:/bin nav history last"`,
+		`t display-popup -E -w 80% -h 80% "HOME=$HOME PATH=$PATH:/bin nav sessionize --filter 'api'"`,
+		`# g: api (popup: lazygit)`,
+	} {
+		if !strings.Contains(tmuxConf, want) {
+			t.Errorf("tmux output missing %q:\n%s", want, tmuxConf)
+		}
+	}
+	for _, key := range []string{"a", "e", "g", "t"} {
+		if strings.Contains(tmuxConf, "-r -T nav-workspaces "+key+" ") {
+			t.Errorf("key %s should not repeat:\n%s", key, tmuxConf)
+		}
+	}
+
+	if got := group.ActionFor("g").ShellCommand("nav", "/src/api"); got != "cd '/src/api' && lazygit" {
+		t.Errorf("popup shell command = %q", got)
+	}
+	if got := group.ActionFor("e").ShellCommand("nav", "/src/api"); got != "nav sessionize --window 'editor' '/src/api'" {
+		t.Errorf("window shell command = %q", got)
+	}
+
+	m := BuildManifest([]GroupBinding{group})
+	for _, b := range m.Groups[0].Bindings {
+		if b.Key == "g" && (b.Action != "popup" || b.Command[0] != "sh") {
+			t.Errorf("unexpected popup manifest entry: %+v", b)
+		}
+		if b.Key == "t" && strings.Join(b.Command, " ") != "nav sessionize --filter api" {
+			t.Errorf("unexpected tui manifest entry: %+v", b)
+		}
+	}
+
+	group.Actions["x"] = Action{Kind: ActionWindow}
+	if _, err := (tmuxGenerator{}).Generate([]GroupBinding{group}, opts); err == nil {
+		t.Error("expected an invalid action to fail generation")
+	}
+}
+
+func TestWindowHotkeys(t *testing.T) {
+	windows := map[string]string{"e": "editor", "t": "tests"}
+	group := GroupBinding{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"a":   {Path: "/src/api"},
+			"g w": {Path: "/src/web"},
+		},
+		Actions: map[string]Action{
+			"a":   {Windows: windows},
+			"g w": {Windows: map[string]string{"t": "tests", "~": "home"}},
+		},
+	}
+	files, err := tmuxGenerator{}.Generate([]GroupBinding{group}, Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := string(files[len(files)-1].Content)
+	for _, want := range []string{
+		"# a: api (sessionize, windows e=editor t=tests)",
+		// Keys with window hotkeys do not repeat: the next key picks a window.
+		`bind-key -T nav-workspaces a run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize '/src/api'" \; switch-client -T nav-workspaces-windows-a`,
+		`bind-key -T nav-workspaces-windows-a e run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize --window 'editor' '/src/api'"`,
+		`bind-key -T nav-workspaces-windows-a t run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize --window 'tests' '/src/api'"`,
+		// Chords enter their window table from the chord's last key.
+		`bind-key -T nav-workspaces-g w run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize '/src/web'" \; switch-client -T nav-workspaces-windows-g-w`,
+		`bind-key -T nav-workspaces-windows-g-w t run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize --window 'tests' '/src/web'"`,
+		// Punctuation keys are quoted; tmux would expand a bare ~.
+		`bind-key -T nav-workspaces-windows-g-w '~' run-shell "HOME=$HOME PATH=$PATH:/bin nav sessionize --window 'home' '/src/web'"`,
+	} {
+		if !strings.Contains(conf, want) {
+			t.Errorf("tmux output missing %q:\n%s", want, conf)
+		}
+	}
+
+	m := BuildManifest([]GroupBinding{group})
+	if got := m.Groups[0].Bindings[0].Windows; len(got) != 2 || got["t"] != "tests" {
+		t.Errorf("manifest windows = %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+package bindings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tmuxKeyNames are tmux's named keys, which are a single step even though
+// they are longer than one character.
+var tmuxKeyNames = map[string]bool{
+	"Up": true, "Down": true, "Left": true, "Right": true,
+	"BSpace": true, "BTab": true, "DC": true, "Delete": true, "End": true,
+	"Enter": true, "Escape": true, "Home": true, "IC": true, "Insert": true,
+	"NPage": true, "PageDown": true, "PgDn": true,
+	"PPage": true, "PageUp": true, "PgUp": true,
+	"Space": true, "Tab": true,
+}
+
+// KeySteps splits a binding key into the keys pressed in sequence. A
+// multi-key chord is written compactly ("gw") or space separated ("g w");
+// the latter is needed when a step is a named or modified tmux key
+// ("g C-a"). A single key such as "a", "C-g" or "F5" is one step.
+func KeySteps(key string) []string {
+	if strings.ContainsAny(key, " \t") {
+		return strings.Fields(key)
+	}
+	if utf8.RuneCountInString(key) <= 1 || isNamedKey(key) {
+		return []string{key}
+	}
+	steps := make([]string, 0, len(key))
+	for _, r := range key {
+		steps = append(steps, string(r))
+	}
+	return steps
+}
+
+// IsChord reports whether key is a multi-key sequence.
+func IsChord(key string) bool {
+	return len(KeySteps(key)) > 1
+}
+
+// FormatKey renders a key for display, separating chord steps with spaces.
+func FormatKey(key string) string {
+	return strings.Join(KeySteps(key), " ")
+}
+
+// ChordConflict returns the first key in bound that cannot coexist with
+// key in one group: the same sequence spelled differently ("gw" and
+// "g w"), or a key that is also the start of a longer chord ("g" and
+// "gw"). Entries equal to key itself are ignored.
+func ChordConflict(key string, bound []string) (string, bool) {
+	steps := KeySteps(key)
+	for _, other := range bound {
+		if other == key {
+			continue
+		}
+		otherSteps := KeySteps(other)
+		n := min(len(steps), len(otherSteps))
+		if n > 0 && equalSteps(steps[:n], otherSteps[:n]) {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// isNamedKey reports whether s is a tmux key name or a modified key
+// ("C-a", "M-Left", "S-F3") rather than a compact chord.
+func isNamedKey(s string) bool {
+	if tmuxKeyNames[s] {
+		return true
+	}
+	if len(s) > 2 && strings.ContainsRune("CMS", rune(s[0])) && s[1] == '-' {
+		return true
+	}
+	if len(s) > 1 && s[0] == 'F' {
+		for _, r := range s[1:] {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isTmuxKey reports whether s is one key tmux can bind: a named or
+// modified key, or a single printable character.
+func isTmuxKey(s string) bool {
+	if isNamedKey(s) {
+		return true
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return size == len(s) && r != utf8.RuneError && unicode.IsGraphic(r) && !unicode.IsSpace(r)
+}
+
+func equalSteps(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
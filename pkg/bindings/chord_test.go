@@ -0,0 +1,48 @@
+package bindings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeySteps(t *testing.T) {
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"a", []string{"a"}},
+		{"gw", []string{"g", "w"}},
+		{"g w", []string{"g", "w"}},
+		{"g C-a", []string{"g", "C-a"}},
+		{"C-g", []string{"C-g"}},
+		{"M-Left", []string{"M-Left"}},
+		{"F5", []string{"F5"}},
+		{"Enter", []string{"Enter"}},
+		{"Fx", []string{"F", "x"}},
+	}
+	for _, tt := range tests {
+		if got := KeySteps(tt.key); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("KeySteps(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestChordConflict(t *testing.T) {
+	tests := []struct {
+		key   string
+		bound []string
+		want  string
+	}{
+		{"g", []string{"a", "gw"}, "gw"},
+		{"gw", []string{"g"}, "g"},
+		{"gw", []string{"g w"}, "g w"},
+		{"gw", []string{"ga", "gwx"}, "gwx"},
+		{"gw", []string{"ga", "w", "gw"}, ""},
+	}
+	for _, tt := range tests {
+		got, ok := ChordConflict(tt.key, tt.bound)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("ChordConflict(%q, %q) = %q, %v; want %q", tt.key, tt.bound, got, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package bindings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", ""},
+		{
+			name: "changed line",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("old", "new", []byte(tt.old), []byte(tt.new))
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPlanAndApply(t *testing.T) {
+	cacheDir := t.TempDir()
+	navDir := filepath.Join(cacheDir, "nav")
+	opts := Options{BinDir: "/bin", CacheDir: cacheDir}
+
+	groups := []GroupBinding{
+		{Name: "default", Prefix: "<prefix>", Sessions: map[string]models.NavSessionConfig{"a": {Path: "/src/a"}}},
+		{Name: "work", Prefix: "<prefix> w", Sessions: map[string]models.NavSessionConfig{"x": {Path: "/work/x"}}},
+	}
+
+	// A group file from a group that has since been deleted.
+	if err := os.MkdirAll(navDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	orphan := filepath.Join(navDir, "generated-bindings-old.conf")
+	if err := os.WriteFile(orphan, []byte("# Group: old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := BuildPlan(groups, opts, []string{"tmux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bindingChanges int
+	workMenu := false
+	for _, c := range plan.Changes {
+		switch filepath.Base(c.Path) {
+		case "generated-menu-work.conf":
+			workMenu = true
+		case "generated-menu-default.conf":
+		default:
+			bindingChanges++
+		}
+	}
+	if bindingChanges != 3 {
+		t.Fatalf("expected 2 new binding files and 1 removal, got %+v", plan.Changes)
+	}
+	if !workMenu {
+		t.Errorf("expected the work group's menu file, got %+v", plan.Changes)
+	}
+	if diff := plan.Diff(); !strings.Contains(diff, "+++ /dev/null") || !strings.Contains(diff, "--- /dev/null") {
+		t.Errorf("diff should show the created and removed files:\n%s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(navDir, "generated-bindings.conf")); !os.IsNotExist(err) {
+		t.Fatal("BuildPlan must not write anything")
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Error("orphaned group file should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(navDir, "generated-bindings-work.conf")); err != nil {
+		t.Errorf("group file not written: %v", err)
+	}
+	entries, _ := os.ReadDir(navDir)
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp-") {
+			t.Errorf("temporary file left behind: %s", e.Name())
+		}
+	}
+
+	plan, err = BuildPlan(groups, opts, []string{"tmux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Errorf("expected no changes after apply, got %+v", plan.Changes)
+	}
+}
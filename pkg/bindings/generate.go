@@ -2,9 +2,8 @@ package bindings
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
 
 	"github.com/grovetools/core/pkg/keygen"
@@ -17,16 +16,46 @@ type GroupBinding struct {
 	Name     string                             // Group name ("default", "grovetools", etc.)
 	Prefix   string                             // Tmux prefix string (e.g. "<prefix>", "<grove> k")
 	Sessions map[string]models.NavSessionConfig // Key → session config
+	Actions  map[string]Action                  // Key → action; keys not listed sessionize their path
+	Icon     string                             // Group icon glyph shown in the which-key menu
 }
 
 // GenerateTmuxConf generates tmux key binding config files for all groups.
 // binDir is the path to the grove bin directory (for nav binary references).
 // cacheDir is the path to the grove cache directory (output location).
+// Keys whose path no longer exists are left out with a comment. History-slot
+// bindings need Options.HistoryPrefix and are only written through
+// BuildPlan, as `nav key regenerate` does.
 func GenerateTmuxConf(groups []GroupBinding, binDir, cacheDir string) error {
-	navCacheDir := filepath.Join(cacheDir, "nav")
-	if err := os.MkdirAll(navCacheDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create nav cache directory: %w", err)
+	return generateAndWrite(tmuxGenerator{}, groups, binDir, cacheDir)
+}
+
+// GenerateTuimuxConf generates tuimux keybinding config for all groups.
+// binDir is the grove bin directory (for nav binary references).
+// cacheDir is the grove cache directory (output location).
+func GenerateTuimuxConf(groups []GroupBinding, binDir, cacheDir string) error {
+	return generateAndWrite(tuimuxGenerator{}, groups, binDir, cacheDir)
+}
+
+// generateAndWrite renders one generator and applies its plan. It has no
+// HistoryPrefix to pass on, so the tmux output carries no history slots.
+func generateAndWrite(g Generator, groups []GroupBinding, binDir, cacheDir string) error {
+	plan, err := buildPlan([]Generator{g}, groups, Options{BinDir: binDir, CacheDir: cacheDir, SkipMissing: true})
+	if err != nil {
+		return err
 	}
+	return plan.Apply()
+}
+
+// tmuxGenerator renders the tmux key tables: a master generated-bindings.conf
+// holding the default group, which sources one file per other group.
+type tmuxGenerator struct{}
+
+func (tmuxGenerator) Name() string { return "tmux" }
+
+func (tmuxGenerator) Generate(groups []GroupBinding, opts Options) ([]OutputFile, error) {
+	binDir := opts.BinDir
+	var files []OutputFile
 
 	var masterBindings strings.Builder
 	masterBindings.WriteString("# Auto-generated nav workspace bindings\n")
@@ -37,12 +66,12 @@ func GenerateTmuxConf(groups []GroupBinding, binDir, cacheDir string) error {
 		if group.Prefix == "" {
 			continue
 		}
-
-		tableName := "nav-workspaces"
-		if group.Name != "default" {
-			tableName = "nav-" + group.Name
+		if err := validateActions(group); err != nil {
+			return nil, err
 		}
 
+		tableName := groupTableName(group.Name)
+
 		cfg := tmuxkeygen.Config{
 			Prefix:    group.Prefix,
 			TableName: tableName,
@@ -53,105 +82,387 @@ func GenerateTmuxConf(groups []GroupBinding, binDir, cacheDir string) error {
 		bindings.WriteString(fmt.Sprintf("# Prefix mode: %s\n\n", group.Prefix))
 
 		if group.Name == "default" {
-			bindings.WriteString("# Hook to track session switches for history\n")
-			bindings.WriteString(fmt.Sprintf("set-hook -g client-session-changed 'run-shell -b \"HOME=$HOME PATH=$PATH:%s nav record-session\"'\n\n", binDir))
+			bindings.WriteString(tmuxHookLines(binDir))
 		}
 
+		helpCmd := "nav key list | less -R"
+		if group.Name != "default" {
+			helpCmd = fmt.Sprintf("nav key list --group %s | less -R", group.Name)
+		}
+		navCmd := fmt.Sprintf("HOME=$HOME PATH=$PATH:%s nav", binDir)
+
+		// Groups with their own key table get a which-key menu, opened with
+		// ? or by pausing after the prefix.
+		menuPath := navOutputPath(opts, menuConfName(group.Name))
+		menuBind, menuTimer := menuTriggers(cfg, menuPath)
+		hasMenu := false
 		entryPoint := cfg.GenerateEntryPoint()
+		for i, line := range entryPoint {
+			if strings.HasSuffix(line, "switch-client -T "+tableName) {
+				entryPoint[i] = line + ` \; ` + menuTimer
+				hasMenu = true
+			}
+		}
 		if len(entryPoint) > 0 {
 			bindings.WriteString(strings.Join(entryPoint, "\n"))
 			bindings.WriteString("\n")
 		}
 
-		helpCmd := "nav key list | less -R"
-		if group.Name != "default" {
-			helpCmd = fmt.Sprintf("nav key list --group %s | less -R", group.Name)
-		}
 		escapeHatches := cfg.GenerateEscapeHatches(helpCmd)
+		if hasMenu {
+			// The menu takes over the help key rather than rebinding it.
+			escapeHatches = slices.DeleteFunc(escapeHatches, func(line string) bool {
+				return bindsTableKey(line, tableName, menuKey)
+			})
+		}
 		if len(escapeHatches) > 0 {
 			bindings.WriteString(strings.Join(escapeHatches, "\n"))
 			bindings.WriteString("\n")
 		}
-
-		sessionizerPath := fmt.Sprintf("HOME=$HOME PATH=$PATH:%s nav sessionize", binDir)
-
-		// Sort sessions by key for consistent output.
-		keys := make([]string, 0, len(group.Sessions))
-		for k := range group.Sessions {
-			keys = append(keys, k)
+		if hasMenu {
+			menu := tmuxMenu(group, MenuItems(group, opts), navCmd, helpCmd)
+			files = append(files, OutputFile{Path: menuPath, Content: []byte(menu)})
+			bindings.WriteString(menuBind + "\n")
 		}
-		sort.Strings(keys)
 
 		bindings.WriteString("# --- Workspace Bindings ---\n")
-		for _, key := range keys {
+		enteredTables := make(map[string]bool)
+		for _, key := range sortedBoundKeys(group) {
 			sess := group.Sessions[key]
-			if sess.Path == "" {
+			if problem := PathQuotingProblem(sess.Path); problem != "" {
+				bindings.WriteString(fmt.Sprintf("# %s: skipped, path %s (run 'nav key validate')\n\n", FormatKey(key), problem))
+				continue
+			}
+			if opts.SkipMissing && MissingPath(sess.Path) {
+				bindings.WriteString(fmt.Sprintf("# %s: skipped, %s does not exist (run 'nav key prune')\n\n", FormatKey(key), sess.Path))
 				continue
 			}
-			comment := fmt.Sprintf("# %s: %s", key, filepath.Base(sess.Path))
+			action := group.ActionFor(key)
+			comment := fmt.Sprintf("# %s: %s", FormatKey(key), filepath.Base(sess.Path))
+			if action.EffectiveKind() != ActionSessionize || len(action.Windows) > 0 {
+				comment += " (" + action.Describe() + ")"
+			}
 			bindings.WriteString(comment + "\n")
-			actionPart := fmt.Sprintf("run-shell \"%s '%s'\"", sessionizerPath, sess.Path)
-			bindCmd := cfg.FormatBindKey(key, actionPart, "-r")
-			bindings.WriteString(bindCmd + "\n\n")
+			actionPart := tmuxAction(action, navCmd, sess.Path)
+			var windowLines []string
+			if len(action.Windows) > 0 {
+				// Once in the project, the client waits in the key's window
+				// table for a window hotkey.
+				table := windowTable(tableName, key)
+				actionPart += ` \; switch-client -T ` + table
+				windowLines = windowBindings(table, action, navCmd, sess.Path)
+			}
+			if steps := KeySteps(key); len(steps) > 1 {
+				for _, line := range chordBindings(cfg, tableName, steps, actionPart, enteredTables) {
+					bindings.WriteString(line + "\n")
+				}
+			} else {
+				flags := ""
+				if action.Repeats() {
+					flags = "-r"
+				}
+				bindings.WriteString(cfg.FormatBindKey(key, actionPart, flags) + "\n")
+			}
+			for _, line := range windowLines {
+				bindings.WriteString(line + "\n")
+			}
+			bindings.WriteString("\n")
 		}
 
 		if group.Name == "default" {
 			masterBindings.WriteString(bindings.String())
 		} else {
-			groupFilename := fmt.Sprintf("generated-bindings-%s.conf", group.Name)
-			groupFile := filepath.Join(navCacheDir, groupFilename)
-			if err := os.WriteFile(groupFile, []byte(bindings.String()), 0o600); err != nil {
-				return fmt.Errorf("failed to write group bindings file %s: %w", groupFilename, err)
-			}
+			groupFile := navOutputPath(opts, fmt.Sprintf("generated-bindings-%s.conf", group.Name))
+			files = append(files, OutputFile{Path: groupFile, Content: []byte(bindings.String())})
 			masterBindings.WriteString(fmt.Sprintf("\n# Source group: %s\n", group.Name))
-			masterBindings.WriteString(fmt.Sprintf("source-file %s\n", groupFile))
+			masterBindings.WriteString(fmt.Sprintf("source-file %s\n", tmuxQuote(groupFile)))
 		}
 	}
 
-	masterFile := filepath.Join(navCacheDir, "generated-bindings.conf")
-	return os.WriteFile(masterFile, []byte(masterBindings.String()), 0o600)
+	if opts.HistoryPrefix != "" {
+		masterBindings.WriteString(historySlotBindings(groups, opts.HistoryPrefix, binDir))
+	}
+
+	files = append(files, OutputFile{
+		Path:    navOutputPath(opts, "generated-bindings.conf"),
+		Content: []byte(masterBindings.String()),
+	})
+	return files, nil
 }
 
-// GenerateTuimuxConf generates tuimux keybinding config for all groups.
-// binDir is the grove bin directory (for nav binary references).
-// cacheDir is the grove cache directory (output location).
-func GenerateTuimuxConf(groups []GroupBinding, binDir, cacheDir string) error {
-	navCacheDir := filepath.Join(cacheDir, "nav")
-	if err := os.MkdirAll(navCacheDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create nav cache directory: %w", err)
+// TmuxHookIndex is the index nav sets in each tmux hook array. A fixed
+// index of its own leaves hooks set by the user or other plugins alone
+// (unlike a plain set-hook, which replaces index 0), and sourcing the
+// bindings again replaces nav's hooks rather than appending duplicates (as
+// set-hook -a would).
+const TmuxHookIndex = 970
+
+// tmuxHooks are the hooks nav installs for access history, with the
+// record-session arguments each runs. Session names come from hook
+// formats, shell-quoted with q:.
+var tmuxHooks = []struct{ event, args string }{
+	{"client-session-changed", ""},
+	{"session-closed", " --event closed --session #{q:hook_session_name}"},
+	{"client-detached", " --event detached --session #{q:session_name}"},
+}
+
+// TmuxHookEvents returns the tmux hooks nav installs.
+func TmuxHookEvents() []string {
+	events := make([]string, len(tmuxHooks))
+	for i, h := range tmuxHooks {
+		events[i] = h.event
 	}
+	return events
+}
+
+// tmuxHookLines renders nav's hooks for the default group's file.
+func tmuxHookLines(binDir string) string {
+	var b strings.Builder
+	b.WriteString("# Hooks to track session switches and ends for history\n")
+	for _, h := range tmuxHooks {
+		b.WriteString(fmt.Sprintf("set-hook -g %s[%d] 'run-shell -b \"HOME=$HOME PATH=$PATH:%s nav record-session%s\"'\n",
+			h.event, TmuxHookIndex, binDir, h.args))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// historySlots is the number of history-slot hotkeys, bound to keys 1-9.
+const historySlots = 9
+
+// historySlotBindings renders keys 1-9 under prefix, each running
+// `nav history goto N` so the slot is resolved when the key is pressed.
+// When a group uses the same prefix, the slots go into that group's key
+// table instead of a table of their own, which would replace the group's
+// entry point, and a slot key the group already binds is left to it.
+func historySlotBindings(groups []GroupBinding, prefix, binDir string) string {
+	cfg := tmuxkeygen.Config{Prefix: prefix, TableName: "nav-history"}
+	shared := false
+	taken := make(map[string]bool)
+	for _, g := range groups {
+		if g.Prefix != prefix {
+			continue
+		}
+		cfg.TableName = groupTableName(g.Name)
+		shared = true
+		for _, key := range sortedBoundKeys(g) {
+			taken[KeySteps(key)[0]] = true
+		}
+		break
+	}
+
+	var b strings.Builder
+	b.WriteString("\n# --- History Slots ---\n")
+	b.WriteString(fmt.Sprintf("# Prefix mode: %s\n", prefix))
+	if !shared {
+		if entryPoint := cfg.GenerateEntryPoint(); len(entryPoint) > 0 {
+			b.WriteString(strings.Join(entryPoint, "\n") + "\n")
+		}
+	}
+	navCmd := fmt.Sprintf("HOME=$HOME PATH=$PATH:%s nav", binDir)
+	for slot := 1; slot <= historySlots; slot++ {
+		key := fmt.Sprint(slot)
+		if taken[key] {
+			b.WriteString(fmt.Sprintf("# %s: skipped, bound by a workspace group\n", key))
+			continue
+		}
+		b.WriteString(cfg.FormatBindKey(key, fmt.Sprintf("run-shell \"%s history goto %d\"", navCmd, slot), "") + "\n")
+	}
+	return b.String()
+}
+
+// tmuxAction renders the tmux command a key runs. Background actions go
+// through run-shell with navCmd (nav with the grove bin dir on PATH);
+// interactive ones open a popup. navCmd is written as is, so its $HOME and
+// $PATH are expanded by tmux; the path and other values are quoted for the
+// shell and then escaped for tmux (see quote.go). Configured commands are
+// already shell syntax and are only escaped for tmux, so the shell sees
+// them as written.
+func tmuxAction(a Action, navCmd, path string) string {
+	switch a.EffectiveKind() {
+	case ActionPopup:
+		return fmt.Sprintf("display-popup -E -d %s \"%s\"", tmuxQuote(formatLiteral(path)), tmuxEscape(a.Command))
+	case ActionTUI:
+		return fmt.Sprintf("display-popup -E -w 80%% -h 80%% \"%s %s\"", navCmd, tmuxEscape(shellArgs(a.NavArgs(path))))
+	case ActionNav:
+		return fmt.Sprintf("run-shell \"%s %s\"", navCmd, tmuxEscape(a.Command))
+	default:
+		return fmt.Sprintf("run-shell \"%s %s\"", navCmd, tmuxEscape(shellArgs(a.NavArgs(path))))
+	}
+}
+
+// chordTable names the tmux key table entered after typing steps of a chord
+// in table, e.g. "nav-workspaces-g" after "g".
+func chordTable(table string, steps []string) string {
+	return table + "-" + strings.Join(steps, "-")
+}
+
+// windowTable names the tmux key table entered after key has switched to
+// its project, e.g. "nav-workspaces-windows-a", where the project's window
+// hotkeys are bound.
+func windowTable(table, key string) string {
+	return table + "-windows-" + strings.Join(KeySteps(key), "-")
+}
+
+// windowBindings renders the window hotkeys of action in table: each
+// selects its window in the session for path, creating it if needed.
+func windowBindings(table string, action Action, navCmd, path string) []string {
+	var lines []string
+	for _, key := range action.WindowKeys() {
+		lines = append(lines, fmt.Sprintf("bind-key -T %s %s %s", table, tmuxKey(key), tmuxAction(action.WindowAction(key), navCmd, path)))
+	}
+	return lines
+}
+
+// chordBindings renders a multi-key chord as nested tmux key tables: each
+// leading key switches the client into the table for the keys typed so
+// far, and the last key runs action. Tables already entered by an earlier
+// chord of the group (tracked in entered) are not bound again. Chords are
+// not repeatable, so the first key is bound without -r.
+func chordBindings(cfg tmuxkeygen.Config, table string, steps []string, action string, entered map[string]bool) []string {
+	var lines []string
+	for i := 0; i < len(steps)-1; i++ {
+		next := chordTable(table, steps[:i+1])
+		if entered[next] {
+			continue
+		}
+		entered[next] = true
+		enter := "switch-client -T " + next
+		if i == 0 {
+			lines = append(lines, cfg.FormatBindKey(steps[0], enter, ""))
+		} else {
+			lines = append(lines, fmt.Sprintf("bind-key -T %s %s %s", chordTable(table, steps[:i]), tmuxKey(steps[i]), enter))
+		}
+	}
+	last := chordTable(table, steps[:len(steps)-1])
+	lines = append(lines, fmt.Sprintf("bind-key -T %s %s %s", last, tmuxKey(steps[len(steps)-1]), action))
+	return lines
+}
+
+// StaleFiles returns generated-bindings-<group>.conf and
+// generated-menu-<group>.conf files left behind by groups that no longer
+// exist (or no longer have a prefix).
+func (tmuxGenerator) StaleFiles(opts Options, produced []OutputFile) ([]string, error) {
+	var matches []string
+	for _, pattern := range []string{"generated-bindings-*.conf", "generated-menu-*.conf"} {
+		found, err := filepath.Glob(navOutputPath(opts, pattern))
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, found...)
+	}
+	keep := make(map[string]bool, len(produced))
+	for _, f := range produced {
+		keep[f.Path] = true
+	}
+	var stale []string
+	for _, path := range matches {
+		if !keep[path] {
+			stale = append(stale, path)
+		}
+	}
+	return stale, nil
+}
+
+// tuimuxGenerator renders the tuimux keybinding TOML. Each binding's key
+// is a key sequence that starts with its group's prefix ("<prefix> w a"),
+// so the same key in two groups stays apart the way the tmux key tables
+// keep it apart. Chords and window hotkeys continue the sequence. A
+// [[groups]] entry per group carries its name, icon and prefix for display.
+type tuimuxGenerator struct{}
+
+func (tuimuxGenerator) Name() string { return "tuimux" }
+
+func (tuimuxGenerator) Generate(groups []GroupBinding, opts Options) ([]OutputFile, error) {
+	var b strings.Builder
+	b.WriteString("# Auto-generated nav workspace bindings for tuimux\n")
+	b.WriteString("# Generated by grove nav\n")
 
 	var allBindings []keygen.TuimuxBinding
 	for _, group := range groups {
 		if group.Prefix == "" {
 			continue
 		}
-
-		keys := make([]string, 0, len(group.Sessions))
-		for k := range group.Sessions {
-			keys = append(keys, k)
+		if err := validateActions(group); err != nil {
+			return nil, err
 		}
-		sort.Strings(keys)
 
-		for _, key := range keys {
-			sess := group.Sessions[key]
-			if sess.Path == "" {
+		b.WriteString("\n[[groups]]\n")
+		fmt.Fprintf(&b, "name = %s\n", tomlString(group.Name))
+		fmt.Fprintf(&b, "icon = %s\n", tomlString(group.Icon))
+		fmt.Fprintf(&b, "prefix = %s\n", tomlString(group.Prefix))
+
+		menuBound := false
+		for _, key := range sortedBoundKeys(group) {
+			if problem := PathQuotingProblem(group.Sessions[key].Path); problem != "" {
+				fmt.Fprintf(&b, "# %s: skipped, path %s\n", FormatKey(key), problem)
 				continue
 			}
-			cmd := fmt.Sprintf("nav sessionize '%s'", sess.Path)
+			if key == menuKey {
+				menuBound = true
+			}
+			seq := group.Prefix + " " + FormatKey(key)
+			action := group.ActionFor(key)
+			cmd, style := tuimuxAction(action, group.Sessions[key].Path)
 			allBindings = append(allBindings, keygen.TuimuxBinding{
-				Key:            key,
+				Key:            seq,
 				Command:        cmd,
-				Style:          "run-shell",
+				Style:          style,
 				ExitOnComplete: true,
 			})
+			// Window hotkeys follow the key, as in its tmux window table.
+			for _, winKey := range action.WindowKeys() {
+				cmd, style := tuimuxAction(action.WindowAction(winKey), group.Sessions[key].Path)
+				allBindings = append(allBindings, keygen.TuimuxBinding{
+					Key:            seq + " " + winKey,
+					Command:        cmd,
+					Style:          style,
+					ExitOnComplete: true,
+				})
+			}
+		}
+
+		// The group's which-key menu, unless the group maps ? itself.
+		if !menuBound {
+			allBindings = append(allBindings, keygen.TuimuxBinding{
+				Key:     group.Prefix + " " + menuKey,
+				Command: "nav key menu --group " + shellQuote(group.Name),
+				Style:   "popup",
+			})
 		}
 	}
 
 	cfg := &keygen.TuimuxConfig{
 		Bindings: allBindings,
 	}
-	toml := cfg.GenerateTOML()
-	outFile := filepath.Join(navCacheDir, "generated-bindings-tuimux.toml")
-	return os.WriteFile(outFile, []byte(toml), 0o600)
+	b.WriteString("\n" + cfg.GenerateTOML())
+
+	return []OutputFile{{
+		Path:    navOutputPath(opts, "generated-bindings-tuimux.toml"),
+		Content: []byte(b.String()),
+	}}, nil
+}
+
+// groupTableName names the tmux key table a group's prefix enters.
+func groupTableName(group string) string {
+	if group == "default" {
+		return "nav-workspaces"
+	}
+	return "nav-" + group
+}
+
+// tomlString renders s as a quoted TOML basic string.
+func tomlString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// tuimuxAction renders the command and binding style for a tuimux key.
+// Interactive actions use tuimux's popup style.
+func tuimuxAction(a Action, path string) (cmd, style string) {
+	style = "run-shell"
+	if kind := a.EffectiveKind(); kind == ActionPopup || kind == ActionTUI {
+		style = "popup"
+	}
+	return a.ShellCommand("nav", path), style
 }
@@ -0,0 +1,284 @@
+package bindings
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Options carries the environment a Generator renders against.
+type Options struct {
+	BinDir   string // grove bin directory (for nav binary references)
+	CacheDir string // grove cache directory; output goes under <CacheDir>/nav
+	// SkipMissing leaves keys whose path no longer exists out of the tmux
+	// bindings, with a comment in their place.
+	SkipMissing bool
+	// HistoryPrefix is the prefix under which keys 1-9 jump to the Nth
+	// most recently used project; empty disables history slots.
+	HistoryPrefix string
+}
+
+// OutputFile is one file produced by a Generator.
+type OutputFile struct {
+	Path    string
+	Content []byte
+}
+
+// Generator renders nav bindings for one target (a multiplexer, a shell,
+// or a manifest for other tools). Generators only render; a Plan compares
+// the result with disk and applies it.
+type Generator interface {
+	// Name is the target name selected with `nav key regenerate --target`.
+	Name() string
+	// Generate renders the output files for groups.
+	Generate(groups []GroupBinding, opts Options) ([]OutputFile, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Generator)
+)
+
+// Register adds a generator to the registry. It panics if a generator with
+// the same name is already registered.
+func Register(g Generator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[g.Name()]; exists {
+		panic(fmt.Sprintf("bindings: generator %q registered twice", g.Name()))
+	}
+	registry[g.Name()] = g
+}
+
+// Lookup returns the registered generator for a target name.
+func Lookup(name string) (Generator, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	g, ok := registry[name]
+	return g, ok
+}
+
+// Targets returns the names of all registered generators, sorted.
+func Targets() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultTargets are the targets regenerated after every binding change,
+// so nav works regardless of which mux is active at runtime.
+func DefaultTargets() []string {
+	return []string{"tmux", "tuimux"}
+}
+
+// ResolveTargets expands a list of target names ("all" selects every
+// registered generator) and rejects unknown names.
+func ResolveTargets(names []string) ([]Generator, error) {
+	if len(names) == 0 {
+		names = DefaultTargets()
+	}
+	var gens []Generator
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		expanded := []string{name}
+		if name == "all" {
+			expanded = Targets()
+		}
+		for _, n := range expanded {
+			if seen[n] {
+				continue
+			}
+			g, ok := Lookup(n)
+			if !ok {
+				return nil, fmt.Errorf("unknown binding target %q (available: %s)", n, strings.Join(Targets(), ", "))
+			}
+			seen[n] = true
+			gens = append(gens, g)
+		}
+	}
+	return gens, nil
+}
+
+// StaleFileFinder is implemented by generators that own a family of files
+// (e.g. one per group) and can name the ones a previous run left behind.
+type StaleFileFinder interface {
+	// StaleFiles returns existing files owned by the generator that are
+	// not among produced.
+	StaleFiles(opts Options, produced []OutputFile) ([]string, error)
+}
+
+// FileChange is a pending change to one generated file.
+type FileChange struct {
+	Path   string
+	Target string
+	Old    []byte // current content on disk (nil if the file is missing)
+	New    []byte // content to write (nil when Remove is set)
+	Remove bool   // stale file to delete
+}
+
+// Plan is the set of file changes a regeneration would make. Unchanged
+// files are not included.
+type Plan struct {
+	Changes []FileChange
+}
+
+// BuildPlan renders every target in memory and compares the result with
+// the files on disk, including stale files that would be removed.
+func BuildPlan(groups []GroupBinding, opts Options, targets []string) (*Plan, error) {
+	gens, err := ResolveTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+	return buildPlan(gens, groups, opts)
+}
+
+// buildPlan renders gens and diffs their output against disk.
+func buildPlan(gens []Generator, groups []GroupBinding, opts Options) (*Plan, error) {
+	plan := &Plan{}
+	for _, g := range gens {
+		files, err := g.Generate(groups, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s bindings: %w", g.Name(), err)
+		}
+		for _, f := range files {
+			old, err := os.ReadFile(f.Path)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to read %s: %w", f.Path, err)
+			}
+			if err == nil && bytes.Equal(old, f.Content) {
+				continue
+			}
+			plan.Changes = append(plan.Changes, FileChange{Path: f.Path, Target: g.Name(), Old: old, New: f.Content})
+		}
+
+		finder, ok := g.(StaleFileFinder)
+		if !ok {
+			continue
+		}
+		stale, err := finder.StaleFiles(opts, files)
+		if err != nil {
+			return nil, fmt.Errorf("%s bindings: %w", g.Name(), err)
+		}
+		for _, path := range stale {
+			old, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			plan.Changes = append(plan.Changes, FileChange{Path: path, Target: g.Name(), Old: old, Remove: true})
+		}
+	}
+	return plan, nil
+}
+
+// Diff renders the plan as a unified diff against the files on disk.
+func (p *Plan) Diff() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		oldName, newName := "a"+c.Path, "b"+c.Path
+		if c.Old == nil {
+			oldName = "/dev/null"
+		}
+		if c.Remove {
+			newName = "/dev/null"
+		}
+		b.WriteString(UnifiedDiff(oldName, newName, c.Old, c.New))
+	}
+	return b.String()
+}
+
+// Apply writes every changed file atomically and removes stale files.
+func (p *Plan) Apply() error {
+	for _, c := range p.Changes {
+		if c.Remove {
+			if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove stale %s: %w", c.Path, err)
+			}
+			continue
+		}
+		if err := writeFileAtomic(c.Path, c.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so readers such as tmux's source-file never see a
+// partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// navOutputPath returns the location of a generated file in the nav cache.
+func navOutputPath(opts Options, name string) string {
+	return filepath.Join(opts.CacheDir, "nav", name)
+}
+
+// sortedBoundKeys returns the keys of a group that are bound to a path,
+// sorted for stable output.
+func sortedBoundKeys(group GroupBinding) []string {
+	keys := make([]string, 0, len(group.Sessions))
+	for k, sess := range group.Sessions {
+		if sess.Path != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MissingPath reports whether a mapped path (which may start with ~/) no
+// longer exists, e.g. a deleted worktree or a moved repository.
+func MissingPath(path string) bool {
+	_, err := os.Stat(expandHome(path))
+	return os.IsNotExist(err)
+}
+
+func init() {
+	Register(tmuxGenerator{})
+	Register(tuimuxGenerator{})
+	Register(shellGenerator{shell: "zsh"})
+	Register(shellGenerator{shell: "bash"})
+	Register(shellGenerator{shell: "fish"})
+	Register(zellijGenerator{})
+	Register(manifestGenerator{})
+}
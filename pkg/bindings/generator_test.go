@@ -0,0 +1,378 @@
+package bindings
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func testGroups() []GroupBinding {
+	return []GroupBinding{
+		{
+			Name:   "default",
+			Prefix: "<prefix>",
+			Sessions: map[string]models.NavSessionConfig{
+				"a": {Path: "/src/api"},
+				"b": {Path: "/src/it's"},
+				"c": {}, // unbound slot
+			},
+		},
+		{
+			Name:   "work",
+			Prefix: "<prefix> w",
+			Sessions: map[string]models.NavSessionConfig{
+				"x": {Path: "/work/x"},
+			},
+		},
+		{
+			Name:     "hidden",
+			Prefix:   "",
+			Sessions: map[string]models.NavSessionConfig{"z": {Path: "/hidden"}},
+		},
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	gens, err := ResolveTargets(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != 2 || gens[0].Name() != "tmux" || gens[1].Name() != "tuimux" {
+		t.Errorf("default targets = %v, want tmux, tuimux", gens)
+	}
+
+	gens, err = ResolveTargets([]string{"all", "tmux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != len(Targets()) {
+		t.Errorf("all resolved to %d generators, want %d", len(gens), len(Targets()))
+	}
+
+	if _, err := ResolveTargets([]string{"emacs"}); err == nil {
+		t.Error("expected an error for an unknown target")
+	}
+}
+
+func TestGeneratorsRender(t *testing.T) {
+	opts := Options{BinDir: "/bin", CacheDir: "/cache"}
+
+	tests := []struct {
+		target   string
+		file     string
+		contains []string
+		excludes []string
+	}{
+		{
+			target: "zsh",
+			file:   "generated-bindings.zsh",
+			contains: []string{
+				`__nav_default_a() { nav sessionize '/src/api' </dev/tty; zle reset-prompt }`,
+				`bindkey '\ega' __nav_default_a`,
+				`nav sessionize '/src/it'\''s'`,
+				`bindkey '\egwx' __nav_work_x`,
+			},
+			excludes: []string{"/hidden", "__nav_default_c"},
+		},
+		{
+			target: "bash",
+			file:   "generated-bindings.bash",
+			contains: []string{
+				`__nav_default_a() { nav sessionize '/src/api'; }`,
+				`bind -x '"\ega": __nav_default_a'`,
+			},
+		},
+		{
+			target: "fish",
+			file:   "generated-bindings.fish",
+			contains: []string{
+				`function __nav_work_x; nav sessionize '/work/x'; commandline -f repaint; end`,
+				`bind \egwx __nav_work_x`,
+			},
+		},
+		{
+			target: "tuimux",
+			file:   "generated-bindings-tuimux.toml",
+			contains: []string{
+				"[[groups]]\nname = \"work\"\nicon = \"\"\nprefix = \"<prefix> w\"\n",
+				`"<prefix> a"`,
+				`"nav sessionize '/src/api'"`,
+				`"<prefix> w x"`,
+			},
+			excludes: []string{"/hidden", "[[tables"},
+		},
+		{
+			target: "zellij",
+			file:   "generated-bindings.kdl",
+			contains: []string{
+				`// a: /src/api skipped, zellij sessions cannot be switched by nav`,
+				`// Group work skipped`,
+			},
+			excludes: []string{`bind "a"`, `Run "nav"`, "/work/x", "/hidden"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			g, ok := Lookup(tt.target)
+			if !ok {
+				t.Fatalf("target %q not registered", tt.target)
+			}
+			files, err := g.Generate(testGroups(), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 || files[0].Path != filepath.Join("/cache", "nav", tt.file) {
+				t.Fatalf("unexpected files: %+v", files)
+			}
+			out := string(files[0].Content)
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("output should not contain %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestZellijBindsOnlyNonSwitchingActions(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: "/src/api"},
+			"t": {Path: "/src/api"},
+			"w": {Path: "/src/web"},
+		},
+		Actions: map[string]Action{
+			"t": {Kind: ActionPopup, Command: "make test"},
+			"w": {Kind: ActionWindow, Window: "editor"},
+		},
+	}}
+	files, err := zellijGenerator{}.Generate(groups, Options{CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[0].Content)
+	for _, want := range []string{
+		`bind "t" {`,
+		`Run "sh" "-c" "cd '/src/api' && make test" {`,
+		`// a: /src/api skipped`,
+		`// w: /src/web skipped`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "nav sessionize '") || strings.Contains(out, `"sessionize"`) {
+		t.Errorf("zellij bindings should not run nav sessionize:\n%s", out)
+	}
+}
+
+func TestManifestGenerator(t *testing.T) {
+	g, _ := Lookup("json")
+	files, err := g.Generate(testGroups(), Options{CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(files[0].Content, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if m.Version != ManifestVersion || len(m.Groups) != 2 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+	def := m.Groups[0]
+	if def.Name != "default" || len(def.Bindings) != 2 || def.Bindings[0].Key != "a" {
+		t.Errorf("unexpected default group: %+v", def)
+	}
+	if got := strings.Join(def.Bindings[0].Command, " "); got != "nav sessionize /src/api" {
+		t.Errorf("command = %q", got)
+	}
+}
+
+func TestTmuxChordTables(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"ga":  {Path: "/src/api"},
+			"gw":  {Path: "/src/web"},
+			"gxy": {Path: "/src/deep"},
+		},
+	}}
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[len(files)-1].Content)
+	for _, want := range []string{
+		"# g w: web",
+		"bind-key -T nav-workspaces-g a run-shell",
+		"bind-key -T nav-workspaces-g w run-shell",
+		"bind-key -T nav-workspaces-g x switch-client -T nav-workspaces-g-x",
+		"bind-key -T nav-workspaces-g-x y run-shell",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "switch-client -T nav-workspaces-g\n"); n != 1 {
+		t.Errorf("entry into the g table bound %d times, want 1:\n%s", n, out)
+	}
+}
+
+func TestTmuxSkipsMissingPaths(t *testing.T) {
+	dir := t.TempDir()
+	gone := filepath.Join(dir, "deleted-worktree")
+	groups := []GroupBinding{{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: dir},
+			"b": {Path: gone},
+		},
+	}}
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache", SkipMissing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[len(files)-1].Content)
+	if !strings.Contains(out, "# b: skipped, "+gone+" does not exist") {
+		t.Errorf("missing skip comment:\n%s", out)
+	}
+	if strings.Contains(out, "nav sessionize '"+gone+"'") {
+		t.Errorf("binding generated for a missing path:\n%s", out)
+	}
+	if !strings.Contains(out, "nav sessionize '"+dir+"'") {
+		t.Errorf("binding for an existing path was dropped:\n%s", out)
+	}
+}
+
+func TestTmuxHistorySlots(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:     "default",
+		Prefix:   "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{"3": {Path: "/src/three"}},
+	}}
+
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(files[len(files)-1].Content); strings.Contains(out, "history goto") {
+		t.Errorf("history slots should be off without a prefix:\n%s", out)
+	}
+
+	files, err = tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache", HistoryPrefix: "<prefix>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[len(files)-1].Content)
+	for _, want := range []string{
+		`1 run-shell "HOME=$HOME PATH=$PATH:/bin nav history goto 1"`,
+		`9 run-shell "HOME=$HOME PATH=$PATH:/bin nav history goto 9"`,
+		"# 3: skipped, bound by a workspace group",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "history goto 3") {
+		t.Errorf("slot 3 should be left to the group:\n%s", out)
+	}
+}
+
+func TestTmuxHistorySlotsSharedPrefix(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:     "work",
+		Prefix:   "<grove> w",
+		Sessions: map[string]models.NavSessionConfig{"a": {Path: "/src/api"}, "2": {Path: "/src/two"}},
+	}}
+
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache", HistoryPrefix: "<grove> w"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[len(files)-1].Content)
+	// The slots join the group's table; an entry point of their own would
+	// take the prefix away from the group.
+	if strings.Contains(out, "nav-history") {
+		t.Errorf("history slots should not get their own table:\n%s", out)
+	}
+	for _, want := range []string{
+		`-T nav-work 1 run-shell "HOME=$HOME PATH=$PATH:/bin nav history goto 1"`,
+		"# 2: skipped, bound by a workspace group",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTuimuxGroupsAndSequences(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:   "work",
+		Icon:   "",
+		Prefix: "<grove> w",
+		Sessions: map[string]models.NavSessionConfig{
+			"a":  {Path: "/src/api"},
+			"gw": {Path: "/src/web"},
+		},
+		Actions: map[string]Action{
+			"a": {Windows: map[string]string{"e": "editor"}},
+		},
+	}}
+
+	files, err := tuimuxGenerator{}.Generate(groups, Options{CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[0].Content)
+	for _, want := range []string{
+		// The group's name and icon are carried for display.
+		"[[groups]]\nname = \"work\"\nicon = \"\"\nprefix = \"<grove> w\"\n",
+		`"<grove> w a"`,
+		// Chords and window hotkeys continue the group's key sequence.
+		`"<grove> w g w"`,
+		`"<grove> w a e"`,
+		`"nav sessionize --window 'editor' '/src/api'"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tuimux output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "skipped") {
+		t.Errorf("no binding should be skipped:\n%s", out)
+	}
+}
+
+func TestTmuxHooksIndexed(t *testing.T) {
+	files, err := tmuxGenerator{}.Generate(testGroups(), Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The default group's bindings, hooks included, are in the master file.
+	out := string(files[len(files)-1].Content)
+	for _, want := range []string{
+		`set-hook -g client-session-changed[970] 'run-shell -b "HOME=$HOME PATH=$PATH:/bin nav record-session"'`,
+		`set-hook -g session-closed[970] 'run-shell -b "HOME=$HOME PATH=$PATH:/bin nav record-session --event closed --session #{q:hook_session_name}"'`,
+		`set-hook -g client-detached[970] 'run-shell -b "HOME=$HOME PATH=$PATH:/bin nav record-session --event detached --session #{q:session_name}"'`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q:\n%s", want, out)
+		}
+	}
+	// An unindexed set-hook would replace the user's own hooks.
+	if strings.Contains(out, "set-hook -g client-session-changed ") {
+		t.Errorf("hooks must be set at nav's index:\n%s", out)
+	}
+}
@@ -0,0 +1,87 @@
+package bindings
+
+import (
+	"encoding/json"
+)
+
+// ManifestVersion is the schema version of the JSON bindings manifest.
+const ManifestVersion = 1
+
+// Manifest is the JSON document written by the "json" target, for tools
+// that want nav's bindings without parsing a mux config.
+type Manifest struct {
+	Version int             `json:"version"`
+	Groups  []ManifestGroup `json:"groups"`
+}
+
+// ManifestGroup is one group's bindings in the manifest.
+type ManifestGroup struct {
+	Name     string            `json:"name"`
+	Prefix   string            `json:"prefix"`
+	Bindings []ManifestBinding `json:"bindings"`
+}
+
+// ManifestBinding is a single key binding in the manifest.
+type ManifestBinding struct {
+	Key     string   `json:"key"`
+	Steps   []string `json:"steps"` // keys pressed in sequence; more than one for a chord
+	Path    string   `json:"path"`
+	Action  string   `json:"action"`  // action kind, e.g. "sessionize" or "popup"
+	Command []string `json:"command"` // argv that performs the action
+	// Windows maps keys pressed after Steps to the project windows they select.
+	Windows map[string]string `json:"windows,omitempty"`
+}
+
+// manifestGenerator renders the JSON bindings manifest.
+type manifestGenerator struct{}
+
+func (manifestGenerator) Name() string { return "json" }
+
+func (manifestGenerator) Generate(groups []GroupBinding, opts Options) ([]OutputFile, error) {
+	for _, group := range groups {
+		if group.Prefix == "" {
+			continue
+		}
+		if err := validateActions(group); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.MarshalIndent(BuildManifest(groups), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []OutputFile{{
+		Path:    navOutputPath(opts, "generated-bindings.json"),
+		Content: append(data, '\n'),
+	}}, nil
+}
+
+// BuildManifest converts groups into the JSON manifest structure. Groups
+// without a prefix and unbound keys are omitted, as in every other target.
+func BuildManifest(groups []GroupBinding) Manifest {
+	m := Manifest{Version: ManifestVersion, Groups: []ManifestGroup{}}
+	for _, group := range groups {
+		if group.Prefix == "" {
+			continue
+		}
+		mg := ManifestGroup{Name: group.Name, Prefix: group.Prefix, Bindings: []ManifestBinding{}}
+		for _, key := range sortedBoundKeys(group) {
+			path := group.Sessions[key].Path
+			action := group.ActionFor(key)
+			command := append([]string{"nav"}, action.NavArgs(path)...)
+			if action.EffectiveKind() == ActionPopup {
+				command = []string{"sh", "-c", action.ShellCommand("nav", path)}
+			}
+			mg.Bindings = append(mg.Bindings, ManifestBinding{
+				Key:     key,
+				Steps:   KeySteps(key),
+				Path:    path,
+				Action:  string(action.EffectiveKind()),
+				Command: command,
+				Windows: action.Windows,
+			})
+		}
+		m.Groups = append(m.Groups, mg)
+	}
+	return m
+}
@@ -0,0 +1,138 @@
+package bindings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tmuxkeygen "github.com/grovetools/core/pkg/tmux/keygen"
+)
+
+// menuKey opens a group's which-key menu from inside its key table.
+const menuKey = "?"
+
+// menuDelay is how long, in seconds, a client may sit in a group's key
+// table after its prefix before the which-key menu opens on its own.
+const menuDelay = "1"
+
+// MenuItem is one entry of a group's which-key menu.
+type MenuItem struct {
+	Key    string // Key as bound, e.g. "a" or "g w" for a chord
+	Name   string // Project name (base name of the path)
+	Path   string // Mapped path, with ~ expanded
+	Action Action
+}
+
+// MenuItems returns the entries of group's which-key menu in key order.
+// Keys whose path no longer exists are left out when opts.SkipMissing is
+// set, and keys whose path cannot be quoted always are, matching the
+// generated bindings.
+func MenuItems(group GroupBinding, opts Options) []MenuItem {
+	var items []MenuItem
+	for _, key := range sortedBoundKeys(group) {
+		path := group.Sessions[key].Path
+		if PathQuotingProblem(path) != "" || (opts.SkipMissing && MissingPath(path)) {
+			continue
+		}
+		items = append(items, MenuItem{
+			Key:    key,
+			Name:   filepath.Base(path),
+			Path:   expandHome(path),
+			Action: group.ActionFor(key),
+		})
+	}
+	return items
+}
+
+// menuConfName is the file holding the which-key menu of group.
+func menuConfName(group string) string {
+	return fmt.Sprintf("generated-menu-%s.conf", group)
+}
+
+// tmuxMenu renders group's which-key menu as a tmux config file. Entries
+// show a filled dot when a session rooted at the path is running; the
+// check is a tmux format, so it is current each time the menu opens.
+// helpCmd is the pager the menu's last entry opens.
+func tmuxMenu(group GroupBinding, items []MenuItem, navCmd, helpCmd string) string {
+	icon := ""
+	if group.Icon != "" {
+		icon = group.Icon + " "
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Which-key menu for group: %s\n", group.Name))
+	b.WriteString("# Generated by grove nav\n\n")
+	// The menu can be opened by the pause timer while the client still sits
+	// in the group's table; leave it so the next key after the menu is not
+	// taken as a nav key.
+	b.WriteString("switch-client -T root\n")
+	b.WriteString(fmt.Sprintf("display-menu -T %s -x C -y C \\\n", tmuxQuote("#[align=centre] "+icon+formatLiteral(group.Name))))
+
+	helpBound := false
+	for _, item := range items {
+		label := fmt.Sprintf("%s %s%s", runningFormat(item.Path), icon, formatLiteral(item.Name))
+		key := item.Key
+		if IsChord(key) {
+			// Menu shortcuts are single keys; show the chord instead.
+			label += " [" + FormatKey(key) + "]"
+			key = ""
+		}
+		if key == menuKey {
+			helpBound = true
+		}
+		command := tmuxAction(item.Action, navCmd, item.Path)
+		b.WriteString(fmt.Sprintf("  %s %s %s \\\n", tmuxQuote(label), tmuxQuote(key), tmuxQuote(formatLiteral(command))))
+	}
+
+	helpKey := menuKey
+	if helpBound {
+		helpKey = ""
+	}
+	helpAction := fmt.Sprintf("display-popup -E -w 80%% -h 80%% \"%s\"", helpCmd)
+	b.WriteString("  \"\" \\\n")
+	b.WriteString(fmt.Sprintf("  \"All keys\" %s %s\n", tmuxQuote(helpKey), tmuxQuote(helpAction)))
+	return b.String()
+}
+
+// menuTriggers returns the tmux commands that open the menu in menuPath:
+// the menu key inside the group's table, and a timer appended to its entry
+// point that opens the menu when the client is still in the table after
+// menuDelay.
+func menuTriggers(cfg tmuxkeygen.Config, menuPath string) (bind, timer string) {
+	bind = cfg.FormatBindKey(menuKey, "source-file "+tmuxQuote(menuPath), "")
+	check := fmt.Sprintf(
+		`sleep %s; [ \"\$(tmux display-message -p -c '#{client_name}' '##{client_key_table}')\" = %s ] && tmux source-file %s || true`,
+		menuDelay, tmuxEscape(shellQuote(cfg.TableName)), tmuxEscape(shellQuote(menuPath)))
+	timer = fmt.Sprintf(`run-shell -b "%s"`, check)
+	return bind, timer
+}
+
+// bindsTableKey reports whether line is a bind-key command binding key in
+// table.
+func bindsTableKey(line, table, key string) bool {
+	fields := strings.Fields(line)
+	for i := 0; i+2 < len(fields); i++ {
+		if fields[i] == "-T" && fields[i+1] == table {
+			return strings.Trim(fields[i+2], `"'`) == key
+		}
+	}
+	return false
+}
+
+// runningFormat is a tmux format that expands to a filled dot when a
+// session rooted at path is running and a hollow one otherwise.
+func runningFormat(path string) string {
+	match := fmt.Sprintf("#{?#{==:#{session_path},%s},1,}", conditionLiteral(path))
+	return fmt.Sprintf("#{?#{S:%s},●,○}", match)
+}
+
+// expandHome expands a leading ~/ in path to the home directory.
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,103 @@
+package bindings
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestTmuxMenu(t *testing.T) {
+	group := GroupBinding{
+		Name:   "work",
+		Prefix: "<prefix> w",
+		Icon:   "*",
+		Sessions: map[string]models.NavSessionConfig{
+			"a":   {Path: "/src/a,b#1"},
+			"g w": {Path: "/src/web"},
+			"?":   {Path: "/src/help"},
+		},
+	}
+	menu := tmuxMenu(group, MenuItems(group, Options{}), "HOME=$HOME nav", "nav key list --group work | less -R")
+
+	for _, want := range []string{
+		"switch-client -T root\n",
+		`display-menu -T "#[align=centre] * work" -x C -y C`,
+		// Running check escapes the path for the format; the label only needs # doubled.
+		`"#{?#{S:#{?#{==:#{session_path},/src/a#,b##1},1,}},●,○} * a,b##1" "a"`,
+		// The command is expanded as a format by the menu and again by
+		// run-shell, so # is doubled twice.
+		`run-shell \"HOME=\$HOME nav sessionize '/src/a,b####1'\"`,
+		// Chords have no menu shortcut and show their keys instead.
+		`* web [g w]" ""`,
+		// ? is taken by a project, so the key list entry has no shortcut.
+		`"All keys" "" "display-popup -E -w 80% -h 80% \"nav key list --group work | less -R\""`,
+	} {
+		if !strings.Contains(menu, want) {
+			t.Errorf("menu missing %q:\n%s", want, menu)
+		}
+	}
+}
+
+func TestTmuxMenuTriggers(t *testing.T) {
+	cacheDir := t.TempDir()
+	groups := []GroupBinding{{
+		Name:     "work",
+		Prefix:   "<prefix> w",
+		Sessions: map[string]models.NavSessionConfig{"x": {Path: cacheDir}},
+	}}
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: cacheDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	menuPath := filepath.Join(cacheDir, "nav", "generated-menu-work.conf")
+	var conf, menu string
+	for _, f := range files {
+		switch filepath.Base(f.Path) {
+		case "generated-bindings-work.conf":
+			conf = string(f.Content)
+		case "generated-menu-work.conf":
+			menu = string(f.Content)
+		}
+	}
+	if menu == "" {
+		t.Fatalf("no menu file generated, got %d files", len(files))
+	}
+	if !strings.Contains(conf, `? source-file "`+menuPath+`"`) {
+		t.Errorf("? should open the menu:\n%s", conf)
+	}
+	var helpBinds int
+	for _, line := range strings.Split(conf, "\n") {
+		if bindsTableKey(line, "nav-work", menuKey) {
+			helpBinds++
+		}
+	}
+	if helpBinds != 1 {
+		t.Errorf("? should be bound once, got %d:\n%s", helpBinds, conf)
+	}
+	if !strings.Contains(conf, `switch-client -T nav-work \; run-shell -b "sleep `+menuDelay+`;`) ||
+		!strings.Contains(conf, `'##{client_key_table}')\" = 'nav-work' ] && tmux source-file '`+menuPath+`'`) {
+		t.Errorf("entry point should open the menu after a pause:\n%s", conf)
+	}
+}
+
+func TestTuimuxMenuPopup(t *testing.T) {
+	groups := testGroups()
+	// A ? the user maps in a group keeps its own binding.
+	groups[1].Sessions[menuKey] = models.NavSessionConfig{Path: "/work/help"}
+	files, err := tuimuxGenerator{}.Generate(groups, Options{CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[0].Content)
+	if !strings.Contains(out, `"<prefix> ?"`) || !strings.Contains(out, "nav key menu --group 'default'") {
+		t.Errorf("tuimux config should bind the default group's menu under its prefix:\n%s", out)
+	}
+	if strings.Contains(out, "nav key menu --group 'work'") {
+		t.Errorf("the work group maps ? itself:\n%s", out)
+	}
+	if strings.Contains(out, `"?"`) || strings.Contains(out, "--all-groups") {
+		t.Errorf("the menu should not be bound outside the group prefixes:\n%s", out)
+	}
+}
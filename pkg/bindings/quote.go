@@ -0,0 +1,87 @@
+package bindings
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mapped paths end up in three layers of syntax: a POSIX shell command,
+// nested in a double-quoted tmux config string, which tmux expands as a
+// format before running it. Each layer is escaped on its own, innermost
+// first, so a path is never spliced into a command unquoted.
+
+// shellQuote single-quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellArgs renders nav arguments for a shell command line. The
+// subcommand and flags are literal; values are quoted.
+func shellArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if i > 0 && !strings.HasPrefix(arg, "--") {
+			arg = shellQuote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// tmuxEscape escapes s for the inside of a double-quoted tmux string that
+// is expanded as a format, such as the argument of run-shell: \ and " end
+// the string, $ starts an environment reference and # a format.
+func tmuxEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "#", "##").Replace(s)
+}
+
+// tmuxQuote double-quotes s for a tmux config file. $ is escaped so the
+// config parser leaves environment references for the command to expand.
+func tmuxQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(s) + `"`
+}
+
+// tmuxKey quotes key for a bind-key command. Letters, digits and named
+// keys are written as is. Anything else is single-quoted, since tmux
+// expands a ~ even inside double quotes; a single quote itself is
+// double-quoted.
+func tmuxKey(key string) string {
+	if isNamedKey(key) || strings.IndexFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) < 0 {
+		return key
+	}
+	if strings.Contains(key, "'") {
+		return tmuxQuote(key)
+	}
+	return "'" + key + "'"
+}
+
+// formatLiteral escapes s for use as literal text in a tmux format.
+func formatLiteral(s string) string {
+	return strings.ReplaceAll(s, "#", "##")
+}
+
+// conditionLiteral escapes s for use as literal text inside a #{...}
+// format, where commas and braces are also special.
+func conditionLiteral(s string) string {
+	return strings.NewReplacer("#", "##", ",", "#,", "}", "#}").Replace(s)
+}
+
+// PathQuotingProblem explains why path cannot be written into generated
+// bindings, or returns "" when it can. A tmux config line cannot carry a
+// newline or other control character, and tmux and the TOML and KDL
+// targets all need valid UTF-8.
+func PathQuotingProblem(path string) string {
+	if !utf8.ValidString(path) {
+		return "is not valid UTF-8"
+	}
+	for _, r := range path {
+		if unicode.IsControl(r) {
+			return fmt.Sprintf("contains the control character %q", r)
+		}
+	}
+	return ""
+}
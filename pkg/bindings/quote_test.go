@@ -0,0 +1,202 @@
+package bindings
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// quotingPaths are paths that must reach nav unchanged through every
+// layer of quoting.
+var quotingPaths = []struct {
+	name string
+	path string
+}{
+	{"plain", "/src/api"},
+	{"spaces", "/src/my project"},
+	{"single quote", "/src/it's"},
+	{"double quote", `/src/say "hi"`},
+	{"dollar", "/src/$HOME/${x}"},
+	{"command substitution", "/src/$(id)/`id`"},
+	{"semicolon", "/src/a; rm -rf b"},
+	{"backslash", `/src/a\b\`},
+	{"tmux format", "/src/#1 #{session_name} ##"},
+	{"glob and redirect", "/src/*?[x] > out & |"},
+	{"unicode", "/src/café/日本語 ✓"},
+}
+
+// tmuxUnquote undoes tmux's parsing of a double-quoted string that is then
+// expanded as a format, failing on anything tmux would interpret.
+func tmuxUnquote(t *testing.T, s string) string {
+	t.Helper()
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			i++
+			if i == len(s) || !strings.ContainsRune(`\"$`, rune(s[i])) {
+				t.Fatalf("unexpected escape in %q", s)
+			}
+			b.WriteByte(s[i])
+		case '#':
+			i++
+			if i == len(s) || s[i] != '#' {
+				t.Fatalf("unescaped format in %q", s)
+			}
+			b.WriteByte('#')
+		case '"', '$':
+			t.Fatalf("unescaped %c in %q", c, s)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// runShell runs command with sh and returns its output.
+func runShell(t *testing.T, command string) string {
+	t.Helper()
+	out, err := exec.Command("sh", "-c", command).CombinedOutput()
+	if err != nil {
+		t.Fatalf("sh -c %q: %v: %s", command, err, out)
+	}
+	return string(out)
+}
+
+func TestTmuxActionQuoting(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	// Stands in for nav: prints each argument followed by |.
+	const navCmd = "printf '%s|'"
+	actions := []struct {
+		action Action
+		want   func(path string) string
+	}{
+		{Action{}, func(p string) string { return "sessionize|" + p + "|" }},
+		{Action{Kind: ActionWindow, Window: "it's"}, func(p string) string { return "sessionize|--window|it's|" + p + "|" }},
+	}
+	for _, tc := range quotingPaths {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, a := range actions {
+				line := tmuxAction(a.action, navCmd, tc.path)
+				inner, ok := strings.CutPrefix(line, `run-shell "`+navCmd+" ")
+				if !ok || !strings.HasSuffix(inner, `"`) {
+					t.Fatalf("unexpected command %q", line)
+				}
+				command := navCmd + " " + tmuxUnquote(t, strings.TrimSuffix(inner, `"`))
+				if got, want := runShell(t, command), a.want(tc.path); got != want {
+					t.Errorf("%s: ran %q, got %q, want %q", a.action.Describe(), command, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTmuxActionCommandQuoting(t *testing.T) {
+	const navCmd = "nav"
+	commands := []string{
+		"lazygit",
+		`echo "it's \"quoted\""`,
+		`printf '%s' '#{session_name} ## #[fg=red]'`,
+		`x=1; echo "$x" $HOME ${PATH}`,
+		`printf 'a\\b\n'`,
+		`echo done; tmux kill-server`,
+	}
+	for _, command := range commands {
+		for _, a := range []Action{
+			{Kind: ActionPopup, Command: command},
+			{Kind: ActionNav, Command: command},
+		} {
+			line := tmuxAction(a, navCmd, "/src/api")
+			prefix := `run-shell "` + navCmd + " "
+			if a.Kind == ActionPopup {
+				prefix = `display-popup -E -d "/src/api" "`
+			}
+			inner, ok := strings.CutPrefix(line, prefix)
+			if !ok || !strings.HasSuffix(inner, `"`) {
+				t.Fatalf("unexpected command %q", line)
+			}
+			if got := tmuxUnquote(t, strings.TrimSuffix(inner, `"`)); got != command {
+				t.Errorf("%s: tmux passes %q to the shell, want %q", a.Kind, got, command)
+			}
+		}
+	}
+}
+
+func TestTuimuxActionQuoting(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	for _, tc := range quotingPaths {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, _ := tuimuxAction(Action{}, tc.path)
+			command := strings.Replace(cmd, "nav ", "printf '%s|' ", 1)
+			if got, want := runShell(t, command), "sessionize|"+tc.path+"|"; got != want {
+				t.Errorf("ran %q, got %q, want %q", command, got, want)
+			}
+		})
+	}
+}
+
+func TestTmuxKey(t *testing.T) {
+	tests := []struct{ key, want string }{
+		{"a", "a"},
+		{"7", "7"},
+		{"C-a", "C-a"},
+		{"F5", "F5"},
+		{"Enter", "Enter"},
+		{";", `';'`},
+		{"#", `'#'`},
+		{"~", `'~'`},
+		{"$", `'$'`},
+		{`"`, `'"'`},
+		{`\`, `'\'`},
+		{"'", `"'"`},
+	}
+	for _, tt := range tests {
+		if got := tmuxKey(tt.key); got != tt.want {
+			t.Errorf("tmuxKey(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPathQuotingProblem(t *testing.T) {
+	for _, tc := range quotingPaths {
+		if problem := PathQuotingProblem(tc.path); problem != "" {
+			t.Errorf("%s: %q reported as %s", tc.name, tc.path, problem)
+		}
+	}
+	for _, path := range []string{"/src/a\nb", "/src/tab\there", "/src/\x1b[31m", "/src/\xff"} {
+		if PathQuotingProblem(path) == "" {
+			t.Errorf("%q should not be quotable", path)
+		}
+	}
+}
+
+func TestTmuxSkipsUnquotablePaths(t *testing.T) {
+	groups := []GroupBinding{{
+		Name:   "default",
+		Prefix: "<prefix>",
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: "/src/a\nrun-shell evil"},
+			"b": {Path: "/src/it's"},
+		},
+	}}
+	files, err := tmuxGenerator{}.Generate(groups, Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(files[len(files)-1].Content)
+	if strings.Contains(out, "evil") {
+		t.Errorf("unquotable path written to the config:\n%s", out)
+	}
+	if !strings.Contains(out, "# a: skipped, path contains the control character '\\n'") {
+		t.Errorf("missing skip comment:\n%s", out)
+	}
+	if !strings.Contains(out, `nav sessionize '/src/it'\\''s'"`) {
+		t.Errorf("quoted path missing:\n%s", out)
+	}
+}
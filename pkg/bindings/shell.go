@@ -0,0 +1,159 @@
+package bindings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// shellLeader is the key sequence (Alt-g) that starts every nav shell
+// binding. Non-default groups add their prefix trigger key after it, so
+// "<prefix> w" + "a" becomes Alt-g w a.
+const shellLeader = "\x1bg"
+
+// shellGenerator renders line-editor widgets that run each bound key's
+// action (normally `nav sessionize`), for zsh (zle), bash (readline) or fish.
+type shellGenerator struct {
+	shell string
+}
+
+func (g shellGenerator) Name() string { return g.shell }
+
+func (g shellGenerator) Generate(groups []GroupBinding, opts Options) ([]OutputFile, error) {
+	var b strings.Builder
+	b.WriteString("# Auto-generated nav shell bindings\n")
+	b.WriteString("# Generated by grove nav\n")
+	fmt.Fprintf(&b, "# Source this file from your %s config. Bindings start with Alt-g.\n", g.shell)
+
+	for _, group := range groups {
+		if group.Prefix == "" {
+			continue
+		}
+		if err := validateActions(group); err != nil {
+			return nil, err
+		}
+		leader := shellLeader
+		if group.Name != "default" {
+			trigger := extractTriggerKey(group.Prefix)
+			if utf8.RuneCountInString(trigger) != 1 {
+				fmt.Fprintf(&b, "\n# Group %s skipped: prefix %q has no single-key trigger\n", group.Name, group.Prefix)
+				continue
+			}
+			leader += trigger
+		}
+
+		fmt.Fprintf(&b, "\n# Group: %s\n", group.Name)
+		for _, key := range sortedBoundKeys(group) {
+			// Chords of single characters simply extend the key sequence.
+			steps := KeySteps(key)
+			keys := strings.Join(steps, "")
+			if utf8.RuneCountInString(keys) != len(steps) {
+				fmt.Fprintf(&b, "# %s: skipped, only single-character keys are supported\n", FormatKey(key))
+				continue
+			}
+			fn := shellFuncName(group.Name, keys)
+			seq := leader + keys
+			cmd := group.ActionFor(key).ShellCommand("nav", group.Sessions[key].Path)
+			switch g.shell {
+			case "zsh":
+				fmt.Fprintf(&b, "%s() { %s </dev/tty; zle reset-prompt }\n", fn, cmd)
+				fmt.Fprintf(&b, "zle -N %s\n", fn)
+				fmt.Fprintf(&b, "bindkey %s %s\n", zshKeySeq(seq), fn)
+			case "bash":
+				fmt.Fprintf(&b, "%s() { %s; }\n", fn, cmd)
+				fmt.Fprintf(&b, "bind -x '\"%s\": %s'\n", bashKeySeq(seq), fn)
+			case "fish":
+				fmt.Fprintf(&b, "function %s; %s; commandline -f repaint; end\n", fn, cmd)
+				fmt.Fprintf(&b, "bind %s %s\n", fishKeySeq(seq), fn)
+			default:
+				return nil, fmt.Errorf("unsupported shell %q", g.shell)
+			}
+		}
+	}
+
+	return []OutputFile{{
+		Path:    navOutputPath(opts, "generated-bindings."+g.shell),
+		Content: []byte(b.String()),
+	}}, nil
+}
+
+// shellFuncName builds a function name that is valid in every supported
+// shell from a group name and key.
+func shellFuncName(group, key string) string {
+	var b strings.Builder
+	b.WriteString("__nav_")
+	for _, r := range group {
+		if isIdentRune(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	b.WriteByte('_')
+	for _, r := range key {
+		if isIdentRune(r) {
+			b.WriteRune(r)
+		} else {
+			fmt.Fprintf(&b, "x%02x", r)
+		}
+	}
+	return b.String()
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// zshKeySeq renders a key sequence for bindkey.
+func zshKeySeq(seq string) string {
+	var b strings.Builder
+	for _, r := range seq {
+		switch r {
+		case '\x1b':
+			b.WriteString(`\e`)
+		case '\\', '^':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return shellQuote(b.String())
+}
+
+// bashKeySeq renders a key sequence for a readline binding inside
+// bind -x '"...": fn'.
+func bashKeySeq(seq string) string {
+	var b strings.Builder
+	for _, r := range seq {
+		switch r {
+		case '\x1b':
+			b.WriteString(`\e`)
+		case '\\', '"':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case '\'':
+			b.WriteString(`'\''`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fishKeySeq renders a key sequence for fish's bind builtin.
+func fishKeySeq(seq string) string {
+	var b strings.Builder
+	for _, r := range seq {
+		switch {
+		case r == '\x1b':
+			b.WriteString(`\e`)
+		case isIdentRune(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
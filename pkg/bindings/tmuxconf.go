@@ -0,0 +1,156 @@
+package bindings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Markers delimiting the block `nav tmux install` manages in tmux.conf.
+const (
+	tmuxConfBegin = "# >>> nav >>>"
+	tmuxConfEnd   = "# <<< nav <<<"
+)
+
+// UserTmuxConfPath returns the tmux.conf nav installs into: the first of
+// ~/.tmux.conf, $XDG_CONFIG_HOME/tmux/tmux.conf and ~/.config/tmux/tmux.conf
+// that exists, which is the order tmux itself reads them in. It falls back
+// to ~/.tmux.conf when there is none yet.
+func UserTmuxConfPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	candidates := []string{filepath.Join(home, ".tmux.conf")}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		candidates = append(candidates, filepath.Join(xdg, "tmux", "tmux.conf"))
+	}
+	candidates = append(candidates, filepath.Join(home, ".config", "tmux", "tmux.conf"))
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// TmuxConfBlock returns the managed block that sources sourcePath, quoted
+// for tmux so a path with spaces, quotes or $ still loads.
+func TmuxConfBlock(sourcePath string) string {
+	return tmuxConfBegin + "\n" +
+		"# Managed by `nav tmux install`; remove with `nav tmux uninstall`.\n" +
+		"source-file -q " + tmuxQuote(sourcePath) + "\n" +
+		tmuxConfEnd + "\n"
+}
+
+// InstallTmuxConfBlock returns conf with the managed block sourcing
+// sourcePath, replacing an existing block in place or appending a new one.
+// It reports whether conf changed.
+func InstallTmuxConfBlock(conf, sourcePath string) (string, bool) {
+	block := TmuxConfBlock(sourcePath)
+	if start, end, ok := findTmuxConfBlock(conf); ok {
+		updated := conf[:start] + block + conf[end:]
+		return updated, updated != conf
+	}
+	switch {
+	case conf == "":
+	case strings.HasSuffix(conf, "\n\n"):
+	case strings.HasSuffix(conf, "\n"):
+		conf += "\n"
+	default:
+		conf += "\n\n"
+	}
+	return conf + block, true
+}
+
+// RemoveTmuxConfBlock returns conf without the managed block, and whether
+// there was one.
+func RemoveTmuxConfBlock(conf string) (string, bool) {
+	start, end, ok := findTmuxConfBlock(conf)
+	if !ok {
+		return conf, false
+	}
+	before, after := conf[:start], conf[end:]
+	if after == "" {
+		// Drop the blank line InstallTmuxConfBlock put before an appended block.
+		before = strings.TrimRight(before, "\n")
+		if before != "" {
+			before += "\n"
+		}
+	}
+	return before + after, true
+}
+
+// findTmuxConfBlock returns the byte range of the managed block, including
+// the newline after its end marker.
+func findTmuxConfBlock(conf string) (start, end int, ok bool) {
+	start = strings.Index(conf, tmuxConfBegin+"\n")
+	if start < 0 || (start > 0 && conf[start-1] != '\n') {
+		return 0, 0, false
+	}
+	rel := strings.Index(conf[start:], tmuxConfEnd)
+	if rel < 0 {
+		return 0, 0, false
+	}
+	end = start + rel + len(tmuxConfEnd)
+	if end < len(conf) && conf[end] == '\n' {
+		end++
+	}
+	return start, end, true
+}
+
+// InstallTmuxConf adds or updates the managed block in the tmux.conf at
+// path, creating the file if needed. It reports whether the file changed
+// and where an existing file was backed up to.
+func InstallTmuxConf(path, sourcePath string) (changed bool, backup string, err error) {
+	return editTmuxConf(path, func(conf string) (string, bool) {
+		return InstallTmuxConfBlock(conf, sourcePath)
+	})
+}
+
+// UninstallTmuxConf removes the managed block from the tmux.conf at path.
+// It reports whether there was a block and where the file was backed up to.
+func UninstallTmuxConf(path string) (changed bool, backup string, err error) {
+	return editTmuxConf(path, RemoveTmuxConfBlock)
+}
+
+// editTmuxConf applies edit to the file at path, backing it up and
+// rewriting it atomically with its original permissions when it changes.
+func editTmuxConf(path string, edit func(string) (string, bool)) (bool, string, error) {
+	// Edit the target of a symlinked tmux.conf (e.g. from a dotfiles repo)
+	// rather than replacing the link with a regular file.
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	existed := err == nil
+
+	updated, changed := edit(string(data))
+	if !changed {
+		return false, "", nil
+	}
+
+	mode := os.FileMode(0o644)
+	backup := ""
+	if existed {
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		backup = fmt.Sprintf("%s.bak-%s", path, time.Now().Format("20060102-150405"))
+		if err := os.WriteFile(backup, data, mode); err != nil {
+			return false, "", fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err := writeFileAtomic(path, []byte(updated)); err != nil {
+		return false, "", err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return false, "", fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	return true, backup, nil
+}
@@ -0,0 +1,90 @@
+package bindings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTmuxConfBlock(t *testing.T) {
+	block := TmuxConfBlock("/cache/nav/generated-bindings.conf")
+	tests := []struct {
+		name string
+		conf string
+		want string
+	}{
+		{"empty file", "", block},
+		{"appends after a blank line", "set -g mouse on\n", "set -g mouse on\n\n" + block},
+		{"no trailing newline", "set -g mouse on", "set -g mouse on\n\n" + block},
+		{
+			name: "updates in place",
+			conf: "set -g mouse on\n" + TmuxConfBlock("/old/generated-bindings.conf") + "bind r source-file ~/.tmux.conf\n",
+			want: "set -g mouse on\n" + block + "bind r source-file ~/.tmux.conf\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := InstallTmuxConfBlock(tt.conf, "/cache/nav/generated-bindings.conf")
+			if got != tt.want || !changed {
+				t.Fatalf("InstallTmuxConfBlock() = %q, %v; want %q", got, changed, tt.want)
+			}
+			if again, changed := InstallTmuxConfBlock(got, "/cache/nav/generated-bindings.conf"); changed || again != got {
+				t.Errorf("second install changed the file: %q", again)
+			}
+			removed, ok := RemoveTmuxConfBlock(got)
+			if !ok || strings.Contains(removed, "nav >>>") {
+				t.Fatalf("RemoveTmuxConfBlock() = %q, %v", removed, ok)
+			}
+			if want := strings.TrimRight(tt.conf, "\n"); tt.name != "updates in place" && strings.TrimRight(removed, "\n") != want {
+				t.Errorf("uninstall did not restore the original: %q", removed)
+			}
+		})
+	}
+}
+
+func TestTmuxConfBlockQuotesPath(t *testing.T) {
+	path := `/Users/Jane Doe/.cache/$nav "1"/generated-bindings.conf`
+	block := TmuxConfBlock(path)
+	const prefix = `source-file -q "`
+	for _, line := range strings.Split(block, "\n") {
+		inner, ok := strings.CutPrefix(line, prefix)
+		if !ok {
+			continue
+		}
+		if got := tmuxUnquote(t, strings.TrimSuffix(inner, `"`)); got != path {
+			t.Errorf("tmux sources %q, want %q", got, path)
+		}
+		return
+	}
+	t.Fatalf("no quoted source-file line in %q", block)
+}
+
+func TestInstallTmuxConf(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tmux.conf")
+	if err := os.WriteFile(path, []byte("set -g mouse on\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, backup, err := InstallTmuxConf(path, "/cache/nav/generated-bindings.conf")
+	if err != nil || !changed {
+		t.Fatalf("InstallTmuxConf() = %v, %v", changed, err)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "set -g mouse on\n" {
+		t.Errorf("backup holds %q", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o640 {
+		t.Errorf("permissions changed to %v", info.Mode().Perm())
+	}
+
+	if changed, _, _ := InstallTmuxConf(path, "/cache/nav/generated-bindings.conf"); changed {
+		t.Error("second install should be a no-op")
+	}
+
+	if changed, _, err := UninstallTmuxConf(path); err != nil || !changed {
+		t.Fatalf("UninstallTmuxConf() = %v, %v", changed, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "set -g mouse on\n" {
+		t.Errorf("uninstall left %q", data)
+	}
+}
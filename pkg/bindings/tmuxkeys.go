@@ -0,0 +1,106 @@
+package bindings
+
+import "strings"
+
+// TmuxKey is one tmux key binding: the command bound to key in table.
+type TmuxKey struct {
+	Table   string `json:"table"`
+	Key     string `json:"key"`
+	Command string `json:"command"`
+}
+
+// TmuxConfPath returns the master tmux bindings file that users source
+// from their tmux.conf.
+func TmuxConfPath(opts Options) string {
+	return navOutputPath(opts, "generated-bindings.conf")
+}
+
+// ExpectedTmuxKeys renders the tmux target in memory and returns every key
+// binding it would load, across the master and per-group files.
+func ExpectedTmuxKeys(groups []GroupBinding, opts Options) ([]TmuxKey, error) {
+	files, err := tmuxGenerator{}.Generate(groups, opts)
+	if err != nil {
+		return nil, err
+	}
+	var keys []TmuxKey
+	for _, f := range files {
+		keys = append(keys, ParseTmuxKeys(string(f.Content))...)
+	}
+	return keys, nil
+}
+
+// ParseTmuxKeys extracts the bind-key lines from tmux config or from the
+// output of `tmux list-keys`. Bindings without -T go to the prefix table,
+// and -n selects the root table, as in tmux itself. Keys are unquoted, so
+// a generated '.' and the \. printed by list-keys compare equal. Other
+// lines are ignored.
+func ParseTmuxKeys(text string) []TmuxKey {
+	var keys []TmuxKey
+	for _, line := range strings.Split(text, "\n") {
+		cmd, rest := nextToken(strings.TrimSpace(line))
+		if cmd != "bind-key" && cmd != "bind" {
+			continue
+		}
+		k := TmuxKey{Table: "prefix"}
+		var tok string
+		for {
+			tok, rest = nextToken(rest)
+			if !strings.HasPrefix(tok, "-") || len(tok) < 2 {
+				break
+			}
+			switch tok {
+			case "-n":
+				k.Table = "root"
+			case "-T":
+				k.Table, rest = nextToken(rest)
+			case "-N":
+				_, rest = nextToken(rest)
+			}
+		}
+		if tok == "" {
+			continue
+		}
+		k.Key = unquoteTmuxKey(tok)
+		k.Command = strings.TrimSpace(rest)
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// nextToken splits the first whitespace-separated token off s.
+func nextToken(s string) (tok, rest string) {
+	s = strings.TrimLeft(s, " \t")
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// unquoteTmuxKey removes tmux quoting from a key token: single quotes are
+// literal, and a backslash escapes the next character outside them (\;,
+// \#, \\ and the like in list-keys output, \" inside double quotes).
+func unquoteTmuxKey(tok string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(tok); i++ {
+		c := tok[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+				continue
+			}
+		case c == '\'' && quote == 0, c == '"' && quote == 0:
+			quote = c
+			continue
+		case c == '"' && quote == '"':
+			quote = 0
+			continue
+		case c == '\\' && i+1 < len(tok):
+			i++
+			c = tok[i]
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package bindings
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestParseTmuxKeys(t *testing.T) {
+	text := `# Group: default
+set-hook -g client-session-changed[970] 'run-shell -b "nav record-session"'
+bind-key    -T prefix       g                    switch-client -T nav-workspaces
+bind-key -r -T nav-workspaces a run-shell "nav sessionize '/src/a'"
+bind -n C-g switch-client -T nav-work
+bind-key - split-window -v
+bind-key -N "help" -T nav-work ? display-popup
+`
+	want := []TmuxKey{
+		{Table: "prefix", Key: "g", Command: "switch-client -T nav-workspaces"},
+		{Table: "nav-workspaces", Key: "a", Command: `run-shell "nav sessionize '/src/a'"`},
+		{Table: "root", Key: "C-g", Command: "switch-client -T nav-work"},
+		{Table: "prefix", Key: "-", Command: "split-window -v"},
+	}
+	got := ParseTmuxKeys(text)
+	if !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("ParseTmuxKeys() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseTmuxKeysUnquotesKeys(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: `bind-key -T nav-work '.' run-shell "nav sessionize '/src/a'"`, want: "."},
+		{line: `bind-key -T nav-work "'" run-shell "nav sessionize '/src/a'"`, want: "'"},
+		{line: `bind-key -T nav-work '~' run-shell "nav sessionize '/src/a'"`, want: "~"},
+		{line: `bind-key -T nav-work \; run-shell "nav sessionize '/src/a'"`, want: ";"},
+		{line: `bind-key -T nav-work \# run-shell "nav sessionize '/src/a'"`, want: "#"},
+		{line: `bind-key -T nav-work \$ run-shell "nav sessionize '/src/a'"`, want: "$"},
+		{line: `bind-key -T nav-work \' run-shell "nav sessionize '/src/a'"`, want: "'"},
+		{line: `bind-key -T nav-work \\ run-shell "nav sessionize '/src/a'"`, want: `\`},
+		{line: `bind-key -T nav-work \~ run-shell "nav sessionize '/src/a'"`, want: "~"},
+		{line: `bind-key -T nav-work \{ run-shell "nav sessionize '/src/a'"`, want: "{"},
+		{line: `bind-key -T nav-work \" run-shell "nav sessionize '/src/a'"`, want: `"`},
+		{line: `bind-key -T nav-work "M-'" run-shell "nav sessionize '/src/a'"`, want: "M-'"},
+		{line: `bind-key -T nav-work M-\\ run-shell "nav sessionize '/src/a'"`, want: `M-\`},
+	}
+	for _, tt := range tests {
+		got := ParseTmuxKeys(tt.line)
+		if len(got) != 1 || got[0].Key != tt.want {
+			t.Errorf("ParseTmuxKeys(%s) = %+v, want key %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestExpectedTmuxKeys(t *testing.T) {
+	groups := []GroupBinding{
+		{Name: "default", Prefix: "<prefix>", Sessions: map[string]models.NavSessionConfig{"a": {Path: "/src/a"}}},
+		{Name: "work", Prefix: "<prefix> w", Sessions: map[string]models.NavSessionConfig{"x": {Path: "/work/x"}}},
+	}
+	keys, err := ExpectedTmuxKeys(groups, Options{BinDir: "/bin", CacheDir: "/cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, k := range keys {
+		if strings.Contains(k.Command, "nav sessionize") {
+			found[k.Table+" "+k.Key] = true
+		}
+	}
+	if !found["nav-workspaces a"] || !found["nav-work x"] {
+		t.Errorf("expected bindings from both groups, got %+v", keys)
+	}
+}
@@ -3,6 +3,7 @@ package bindings
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/grovetools/core/pkg/models"
@@ -13,12 +14,103 @@ type GroupConfig struct {
 	Prefix string
 }
 
+// Severity grades a validation issue. Errors reject a write; warnings are
+// reported but accepted.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue codes reported by ValidateReport.
+const (
+	CodeEmptyKey       = "empty-key"
+	CodeRelativePath   = "relative-path"
+	CodePrefixConflict = "prefix-conflict"
+	CodeAmbiguousChord = "ambiguous-chord"
+	CodeGroupCollision = "cross-group-collision"
+	CodeUnquotablePath = "unquotable-path"
+	CodeDuplicatePath  = "duplicate-path-across-groups"
+	CodeMissingPath    = "missing-path"
+)
+
+// Issue is one violation found by ValidateReport.
+type Issue struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Group    string   `json:"group"`
+	Key      string   `json:"key,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Report lists every issue in a NavSessionsFile, errors first, then by
+// group ("default" first) and key.
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// HasErrors reports whether any issue is an error.
+func (r *Report) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Errors returns the error-severity issues.
+func (r *Report) Errors() []Issue {
+	var errs []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+// Err returns nil when the report has no errors. Otherwise it returns an
+// error naming every error-severity issue, so all of them can be fixed in
+// one go.
+func (r *Report) Err() error {
+	errs := r.Errors()
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%s", errs[0].Message)
+	}
+	msgs := make([]string, len(errs))
+	for i, issue := range errs {
+		msgs[i] = issue.Message
+	}
+	return fmt.Errorf("%d validation errors: %s", len(errs), strings.Join(msgs, "; "))
+}
+
+func (r *Report) add(code string, severity Severity, group, key, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{
+		Code:     code,
+		Severity: severity,
+		Group:    group,
+		Key:      key,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
 // Validate checks a NavSessionsFile for consistency errors.
 // groupConfigs maps group name to its static configuration (prefix).
 // Rules enforced:
-//  1. No duplicate keys within a group.
-//  2. Paths must be absolute or start with ~.
-//  3. No workspace key in any group may conflict with another group's prefix key.
+//  1. No empty keys (empty-key).
+//  2. Paths must be absolute or start with ~ (relative-path).
+//  3. No workspace key in any group may conflict with another group's prefix key
+//     (prefix-conflict). For a chord, its first key is what conflicts.
+//  4. No bound key in a group may also start one of its chords ("g" and "gw"),
+//     since tmux could never reach the longer sequence (ambiguous-chord).
+//  5. Two groups sharing a prefix may not bind the same key, since both
+//     enter the same key table; the tuimux target in particular keeps only
+//     one of them (cross-group-collision).
+//  6. Paths may not contain control characters such as newlines, or
+//     invalid UTF-8, which no generated config can quote (unquotable-path).
+//
+// It returns the first error only; use ValidateReport for every issue,
+// including warnings.
 //
 // Prefer ValidateAgainstPrevious when you have a prior on-disk state — it
 // tolerates pre-existing rule-3 violations so users can still edit a file
@@ -28,63 +120,159 @@ func Validate(file *models.NavSessionsFile, groupConfigs map[string]GroupConfig)
 }
 
 // ValidateAgainstPrevious runs the same consistency rules as Validate, but
-// suppresses rule-3 (prefix-trigger conflict) errors for conflicts that were
-// already present in prev. Rules 1 and 2 always apply to newFile regardless
-// of prev.
+// suppresses rule-3 (prefix-trigger conflict) and rule-5 (cross-group
+// collision) errors for conflicts that were already present in prev.
+// Rules 1, 2, 4 and 6 always apply to newFile regardless of prev.
 //
 // This exists so a stale sessions.yml (or user config) already in violation
 // does not permanently block all future writes through the daemon: the user
 // must be able to edit the file in order to fix the violation. Only NEW
-// rule-3 conflicts introduced by this write are rejected.
+// rule-3 and rule-5 conflicts introduced by this write are rejected.
 //
 // Passing prev == nil is equivalent to strict mode (the old Validate).
 func ValidateAgainstPrevious(prev, newFile *models.NavSessionsFile, groupConfigs map[string]GroupConfig) error {
+	return checkRules(prev, newFile, groupConfigs).Err()
+}
+
+// ValidateReport checks newFile against every rule and returns all issues.
+// Rules 1-6 (see Validate) are errors, except that rule-3 and rule-5
+// conflicts already present in prev are downgraded to warnings. It also warns about:
+//   - duplicate-path-across-groups: a path mapped in more than one group.
+//   - missing-path: a mapped path that does not exist on this machine.
+//
+// Only this report stats the mapped paths; the checks run on every write
+// (ValidateAgainstPrevious, ValidateConfigChange) skip missing-path.
+func ValidateReport(prev, newFile *models.NavSessionsFile, groupConfigs map[string]GroupConfig) *Report {
+	report := checkRules(prev, newFile, groupConfigs)
 	if newFile == nil {
-		return nil
+		return report
 	}
-
 	newGroups := groupEntries(newFile)
+	checkMissingPaths(newGroups, report)
+	sortIssues(report.Issues, newGroups)
+	return report
+}
+
+// checkRules runs every check of ValidateReport except missing-path, which
+// touches the filesystem.
+func checkRules(prev, newFile *models.NavSessionsFile, groupConfigs map[string]GroupConfig) *Report {
+	report := &Report{}
+	if newFile == nil {
+		return report
+	}
 
-	// Rule 1: No empty keys (map semantics already enforce uniqueness).
+	newGroups := groupEntries(newFile)
 	for _, g := range newGroups {
-		for key := range g.sessions {
+		keys := sortedKeys(g.sessions)
+
+		// Rule 1: No empty keys (map semantics already enforce uniqueness).
+		for _, key := range keys {
 			if key == "" {
-				return fmt.Errorf("group %q: empty key is not allowed", g.name)
+				report.add(CodeEmptyKey, SeverityError, g.name, "", "group %q: empty key is not allowed", g.name)
 			}
 		}
-	}
 
-	// Rule 2: Paths must be absolute or ~-prefixed.
-	for _, g := range newGroups {
-		for key, sess := range g.sessions {
-			if sess.Path == "" {
+		// Rule 2: Paths must be absolute or ~-prefixed.
+		for _, key := range keys {
+			path := g.sessions[key].Path
+			if path == "" {
 				continue // Empty path means unbound key slot.
 			}
-			if !filepath.IsAbs(sess.Path) && !strings.HasPrefix(sess.Path, "~") {
-				return fmt.Errorf("group %q key %q: path %q must be absolute or start with ~", g.name, key, sess.Path)
+			if !filepath.IsAbs(path) && !strings.HasPrefix(path, "~") {
+				report.add(CodeRelativePath, SeverityError, g.name, key, "group %q key %q: path %q must be absolute or start with ~", g.name, key, path)
+			}
+		}
+
+		// Rule 4: Chord prefix ambiguity within a group.
+		checkChordAmbiguity(g, report)
+
+		// Rule 6: Paths must survive quoting into the generated configs.
+		for _, key := range keys {
+			path := g.sessions[key].Path
+			if problem := PathQuotingProblem(path); problem != "" {
+				report.add(CodeUnquotablePath, SeverityError, g.name, key, "group %q key %q: path %q %s", g.name, key, path, problem)
 			}
 		}
 	}
 
 	// Rule 3: Prefix conflict detection — diff-aware against prev.
 	triggerKeys := buildTriggerKeys(groupConfigs)
-	newConflicts := collectPrefixConflicts(newGroups, triggerKeys)
-
 	var prevConflicts map[string]string
 	if prev != nil {
 		prevConflicts = collectPrefixConflicts(groupEntries(prev), triggerKeys)
 	}
+	for _, c := range prefixConflicts(newGroups, triggerKeys) {
+		if _, preexisting := prevConflicts[c.id()]; preexisting {
+			// Pre-existing violation — tolerate.
+			report.add(CodePrefixConflict, SeverityWarning, c.group, c.key, "%s (pre-existing)", c.message())
+			continue
+		}
+		report.add(CodePrefixConflict, SeverityError, c.group, c.key, "%s", c.message())
+	}
+
+	// Rule 5: Cross-group collisions under a shared prefix — diff-aware too.
+	var prevCollisions map[string]bool
+	if prev != nil {
+		prevCollisions = make(map[string]bool)
+		for _, c := range groupCollisions(groupEntries(prev), groupConfigs) {
+			prevCollisions[c.id()] = true
+		}
+	}
+	for _, c := range groupCollisions(newGroups, groupConfigs) {
+		if prevCollisions[c.id()] {
+			report.add(CodeGroupCollision, SeverityWarning, c.group, c.key, "%s (pre-existing)", c.message())
+			continue
+		}
+		report.add(CodeGroupCollision, SeverityError, c.group, c.key, "%s", c.message())
+	}
+
+	checkDuplicatePaths(newGroups, report)
+
+	sortIssues(report.Issues, newGroups)
+	return report
+}
 
-	for conflictID, msg := range newConflicts {
-		if _, preexisting := prevConflicts[conflictID]; preexisting {
-			continue // Pre-existing violation — tolerate.
+// ValidateConfigChange checks file under groupConfigs, the group
+// configuration after a change such as a new prefix. Errors that file
+// already had under prevConfigs are downgraded to warnings, so a change is
+// only rejected for the conflicts it introduces.
+func ValidateConfigChange(file *models.NavSessionsFile, prevConfigs, groupConfigs map[string]GroupConfig) *Report {
+	existing := make(map[string]bool)
+	for _, issue := range checkRules(nil, file, prevConfigs).Errors() {
+		existing[issue.Code+"\x00"+issue.Group+"\x00"+issue.Key] = true
+	}
+	report := checkRules(nil, file, groupConfigs)
+	for i, issue := range report.Issues {
+		if issue.Severity == SeverityError && existing[issue.Code+"\x00"+issue.Group+"\x00"+issue.Key] {
+			report.Issues[i].Severity = SeverityWarning
+			report.Issues[i].Message += " (pre-existing)"
 		}
-		return fmt.Errorf("%s", msg)
 	}
+	return report
+}
 
+// ValidateGroupName checks a name for a new or renamed group. The name
+// ends up in tmux key table names and generated file names, so it is
+// limited to letters, digits, '-', '_' and '.'.
+func ValidateGroupName(name string) error {
+	switch name {
+	case "":
+		return fmt.Errorf("group name cannot be empty")
+	case "default":
+		return fmt.Errorf("group name %q is reserved", name)
+	}
+	for _, r := range name {
+		if !isGroupNameRune(r) {
+			return fmt.Errorf("group name %q may only contain letters, digits, '-', '_' and '.'", name)
+		}
+	}
 	return nil
 }
 
+func isGroupNameRune(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
 // groupEntry bundles a group name with its sessions map for iteration.
 type groupEntry struct {
 	name     string
@@ -92,16 +280,31 @@ type groupEntry struct {
 }
 
 // groupEntries flattens a NavSessionsFile into a slice of (name, sessions)
-// entries, with "default" first.
+// entries, with "default" first and the rest sorted by name.
 func groupEntries(file *models.NavSessionsFile) []groupEntry {
 	entries := make([]groupEntry, 0, 1+len(file.Groups))
 	entries = append(entries, groupEntry{name: "default", sessions: file.Sessions})
-	for name, gs := range file.Groups {
-		entries = append(entries, groupEntry{name: name, sessions: gs.Sessions})
+	names := make([]string, 0, len(file.Groups))
+	for name := range file.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, groupEntry{name: name, sessions: file.Groups[name].Sessions})
 	}
 	return entries
 }
 
+// sortedKeys returns the keys of sessions in sorted order.
+func sortedKeys(sessions map[string]models.NavSessionConfig) []string {
+	keys := make([]string, 0, len(sessions))
+	for key := range sessions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // buildTriggerKeys maps a group's extracted trigger key back to the group
 // that owns it. Groups whose prefix has no trigger key (e.g. "<prefix>") are
 // skipped.
@@ -115,26 +318,176 @@ func buildTriggerKeys(groupConfigs map[string]GroupConfig) map[string]string {
 	return triggerKeys
 }
 
+// prefixConflict is a key in group that starts with owner's prefix
+// trigger key.
+type prefixConflict struct {
+	group, key, owner string
+}
+
+// id identifies the conflict across prev and new states.
+func (c prefixConflict) id() string {
+	return c.group + ":" + c.key
+}
+
+func (c prefixConflict) message() string {
+	return fmt.Sprintf("group %q key %q conflicts with group %q prefix trigger key", c.group, c.key, c.owner)
+}
+
+// prefixConflicts returns every rule-3 conflict in the given groups, in
+// group and key order.
+func prefixConflicts(groups []groupEntry, triggerKeys map[string]string) []prefixConflict {
+	var conflicts []prefixConflict
+	for _, g := range groups {
+		for _, key := range sortedKeys(g.sessions) {
+			if key == "" {
+				continue
+			}
+			ownerGroup, isTrigger := triggerKeys[KeySteps(key)[0]]
+			if !isTrigger || ownerGroup == g.name {
+				continue
+			}
+			conflicts = append(conflicts, prefixConflict{group: g.name, key: key, owner: ownerGroup})
+		}
+	}
+	return conflicts
+}
+
 // collectPrefixConflicts returns every rule-3 conflict in the given groups,
 // keyed by a stable identifier ("<group>:<key>") so two calls over prev and
-// new states can be diffed. The value is the human-readable error string the
-// caller will surface if the conflict is new.
+// new states can be diffed. The value is the human-readable error string.
 func collectPrefixConflicts(groups []groupEntry, triggerKeys map[string]string) map[string]string {
 	conflicts := make(map[string]string)
+	for _, c := range prefixConflicts(groups, triggerKeys) {
+		conflicts[c.id()] = c.message()
+	}
+	return conflicts
+}
+
+// checkChordAmbiguity reports every pair of bound keys in g where one is
+// also the start of the other's chord, or the same chord spelled twice.
+func checkChordAmbiguity(g groupEntry, report *Report) {
+	var bound []string
+	for key, sess := range g.sessions {
+		if key != "" && sess.Path != "" {
+			bound = append(bound, key)
+		}
+	}
+	sort.Strings(bound)
+	for i, key := range bound {
+		for _, other := range bound[i+1:] {
+			if _, ok := ChordConflict(key, []string{other}); ok {
+				report.add(CodeAmbiguousChord, SeverityError, g.name, key,
+					"group %q: keys %q and %q are ambiguous (one is a prefix of the other)", g.name, FormatKey(key), FormatKey(other))
+			}
+		}
+	}
+}
+
+// groupCollision is a key bound by both group and an earlier group
+// (other) that uses the same prefix.
+type groupCollision struct {
+	group, key, other, prefix string
+}
+
+// id identifies the collision across prev and new states.
+func (c groupCollision) id() string {
+	return c.group + ":" + c.key
+}
+
+func (c groupCollision) message() string {
+	return fmt.Sprintf("group %q key %q collides with group %q: both use prefix %q", c.group, c.key, c.other, c.prefix)
+}
+
+// groupCollisions returns every rule-5 collision in the given groups. For
+// groups sharing a prefix, the first in group order owns a key and each
+// later group binding it is reported. Chords collide on their first key,
+// which is what enters the shared table.
+func groupCollisions(groups []groupEntry, groupConfigs map[string]GroupConfig) []groupCollision {
+	type owner struct{ group, prefix string }
+	owners := make(map[string]owner)
+	var collisions []groupCollision
 	for _, g := range groups {
-		for key := range g.sessions {
-			ownerGroup, isTrigger := triggerKeys[key]
-			if !isTrigger || ownerGroup == g.name {
+		prefix := groupConfigs[g.name].Prefix
+		if prefix == "" {
+			continue
+		}
+		for _, key := range sortedKeys(g.sessions) {
+			if key == "" || g.sessions[key].Path == "" {
 				continue
 			}
-			id := g.name + ":" + key
-			conflicts[id] = fmt.Sprintf(
-				"group %q key %q conflicts with group %q prefix trigger key",
-				g.name, key, ownerGroup,
-			)
+			id := prefix + "\x00" + KeySteps(key)[0]
+			o, taken := owners[id]
+			if !taken {
+				owners[id] = owner{g.name, prefix}
+				continue
+			}
+			if o.group != g.name {
+				collisions = append(collisions, groupCollision{group: g.name, key: key, other: o.group, prefix: prefix})
+			}
 		}
 	}
-	return conflicts
+	return collisions
+}
+
+// checkDuplicatePaths warns about a path mapped in more than one group.
+// The first mapping (in group order) is taken as the original; every later
+// one is reported.
+func checkDuplicatePaths(groups []groupEntry, report *Report) {
+	type mapping struct{ group, key string }
+	first := make(map[string]mapping)
+	for _, g := range groups {
+		for _, key := range sortedKeys(g.sessions) {
+			path := g.sessions[key].Path
+			if path == "" {
+				continue
+			}
+			clean := filepath.Clean(path)
+			orig, seen := first[clean]
+			if !seen {
+				first[clean] = mapping{g.name, key}
+				continue
+			}
+			if orig.group != g.name {
+				report.add(CodeDuplicatePath, SeverityWarning, g.name, key,
+					"group %q key %q: path %q is also mapped in group %q key %q", g.name, key, path, orig.group, orig.key)
+			}
+		}
+	}
+}
+
+// checkMissingPaths warns about absolute or ~-prefixed mapped paths that
+// do not exist on this machine. Relative paths are already errors.
+func checkMissingPaths(groups []groupEntry, report *Report) {
+	for _, g := range groups {
+		for _, key := range sortedKeys(g.sessions) {
+			path := g.sessions[key].Path
+			if path == "" || (!filepath.IsAbs(path) && !strings.HasPrefix(path, "~")) {
+				continue
+			}
+			if MissingPath(path) {
+				report.add(CodeMissingPath, SeverityWarning, g.name, key, "group %q key %q: path %q does not exist", g.name, key, path)
+			}
+		}
+	}
+}
+
+// sortIssues orders issues errors first, then by group (in the order of
+// groups) and key.
+func sortIssues(issues []Issue, groups []groupEntry) {
+	groupRank := make(map[string]int, len(groups))
+	for i, g := range groups {
+		groupRank[g.name] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if a.Group != b.Group {
+			return groupRank[a.Group] < groupRank[b.Group]
+		}
+		return a.Key < b.Key
+	})
 }
 
 // extractTriggerKey returns the final key component from a prefix string.
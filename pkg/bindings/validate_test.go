@@ -112,3 +112,252 @@ func TestValidateAgainstPrevious_Rules1And2StillApply(t *testing.T) {
 		t.Fatal("expected rule 2 (relative path) to reject even with prev in same state")
 	}
 }
+
+// TestValidate_ChordPrefixAmbiguity checks rule 4: a bound key may not also
+// start a chord in the same group, while the same chord in two groups and
+// unbound slots are fine.
+func TestValidate_ChordPrefixAmbiguity(t *testing.T) {
+	groupConfigs := map[string]GroupConfig{
+		"default":  {Prefix: "<prefix>"},
+		"personal": {Prefix: "<prefix> w"},
+	}
+
+	ok := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"g":  {}, // unbound slot
+			"gw": {Path: "/src/web"},
+			"ga": {Path: "/src/api"},
+		},
+		Groups: map[string]models.NavGroupState{
+			"personal": {Sessions: map[string]models.NavSessionConfig{"gw": {Path: "/home/notes"}}},
+		},
+	}
+	if err := Validate(ok, groupConfigs); err != nil {
+		t.Fatalf("expected chords to validate, got: %v", err)
+	}
+
+	ambiguous := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"g":  {Path: "/src/go"},
+			"gw": {Path: "/src/web"},
+		},
+	}
+	err := ValidateAgainstPrevious(ambiguous, ambiguous, groupConfigs)
+	if err == nil {
+		t.Fatal("expected a key that starts a chord to be rejected, even if pre-existing")
+	}
+	if !strings.Contains(err.Error(), `"g"`) || !strings.Contains(err.Error(), `"g w"`) {
+		t.Fatalf("error does not name both keys: %v", err)
+	}
+
+	// The first key of a chord conflicts with another group's trigger.
+	trigger := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{"wa": {Path: "/src/a"}},
+	}
+	if err := Validate(trigger, groupConfigs); err == nil {
+		t.Fatal("expected chord starting with a group trigger key to be rejected")
+	}
+}
+
+// TestValidateReport checks that every issue is reported, not just the
+// first, with warnings for duplicate and missing paths and for pre-existing
+// prefix conflicts.
+func TestValidateReport(t *testing.T) {
+	dir := t.TempDir()
+	groupConfigs := map[string]GroupConfig{
+		"default": {Prefix: "<prefix>"},
+		"work":    {Prefix: "<prefix> w"},
+	}
+	prev := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"w": {Path: dir},
+		},
+	}
+	newFile := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"w":  {Path: dir},
+			"r":  {Path: "relative/path"},
+			"m":  {Path: dir + "/gone"},
+			"":   {Path: dir},
+			"g":  {Path: dir},
+			"gx": {Path: dir},
+		},
+		Groups: map[string]models.NavGroupState{
+			"work": {Sessions: map[string]models.NavSessionConfig{
+				"a": {Path: dir},
+			}},
+		},
+	}
+
+	report := ValidateReport(prev, newFile, groupConfigs)
+
+	type want struct {
+		code     string
+		severity Severity
+		group    string
+		key      string
+	}
+	var got []want
+	for _, issue := range report.Issues {
+		got = append(got, want{issue.Code, issue.Severity, issue.Group, issue.Key})
+	}
+	expected := []want{
+		{CodeEmptyKey, SeverityError, "default", ""},
+		{CodeAmbiguousChord, SeverityError, "default", "g"},
+		{CodeRelativePath, SeverityError, "default", "r"},
+		{CodeMissingPath, SeverityWarning, "default", "m"},
+		{CodePrefixConflict, SeverityWarning, "default", "w"},
+		{CodeDuplicatePath, SeverityWarning, "work", "a"},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("got %d issues, want %d: %+v", len(got), len(expected), report.Issues)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("issue %d = %+v, want %+v", i, got[i], expected[i])
+		}
+	}
+
+	if !report.HasErrors() {
+		t.Fatal("expected report to have errors")
+	}
+	err := report.Err()
+	if err == nil || !strings.Contains(err.Error(), "3 validation errors") {
+		t.Errorf("Err() = %v, want all three errors", err)
+	}
+	if err := ValidateAgainstPrevious(prev, newFile, groupConfigs); err == nil {
+		t.Error("expected ValidateAgainstPrevious to reject the file")
+	}
+
+	warningsOnly := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{"m": {Path: dir + "/gone"}},
+	}
+	if err := ValidateAgainstPrevious(nil, warningsOnly, groupConfigs); err != nil {
+		t.Errorf("warnings must not reject a write, got: %v", err)
+	}
+	if issues := checkRules(nil, warningsOnly, groupConfigs).Issues; len(issues) != 0 {
+		t.Errorf("write-time checks reported %+v, want missing-path left to ValidateReport", issues)
+	}
+}
+
+// TestValidate_CrossGroupCollision checks rule 5: groups sharing a prefix
+// enter the same key table, so they may not bind the same key.
+func TestValidate_CrossGroupCollision(t *testing.T) {
+	groupConfigs := map[string]GroupConfig{
+		"default": {Prefix: "<prefix>"},
+		"shared":  {Prefix: "<prefix>"},
+		"work":    {Prefix: "<prefix> w"},
+	}
+	file := func(shared map[string]models.NavSessionConfig) *models.NavSessionsFile {
+		return &models.NavSessionsFile{
+			Sessions: map[string]models.NavSessionConfig{"a": {Path: "/src/a"}},
+			Groups: map[string]models.NavGroupState{
+				"shared": {Sessions: shared},
+				"work":   {Sessions: map[string]models.NavSessionConfig{"a": {Path: "/work/a"}}},
+			},
+		}
+	}
+
+	if err := Validate(file(map[string]models.NavSessionConfig{"b": {Path: "/src/b"}}), groupConfigs); err != nil {
+		t.Errorf("same key under different prefixes should be valid, got: %v", err)
+	}
+
+	colliding := file(map[string]models.NavSessionConfig{"a g": {Path: "/src/ag"}})
+	err := Validate(colliding, groupConfigs)
+	if err == nil || !strings.Contains(err.Error(), `group "shared" key "a g" collides with group "default"`) {
+		t.Fatalf("expected a cross-group collision, got: %v", err)
+	}
+
+	if err := ValidateAgainstPrevious(colliding, colliding, groupConfigs); err != nil {
+		t.Errorf("pre-existing collision should be tolerated, got: %v", err)
+	}
+	var collisions []Issue
+	for _, issue := range ValidateReport(colliding, colliding, groupConfigs).Issues {
+		if issue.Code == CodeGroupCollision {
+			collisions = append(collisions, issue)
+		}
+	}
+	if len(collisions) != 1 || collisions[0].Severity != SeverityWarning {
+		t.Errorf("expected one pre-existing collision warning, got %+v", collisions)
+	}
+}
+
+// TestValidate_UnquotablePath checks rule 6: paths that no generated
+// config can quote are rejected, even when already present in prev.
+func TestValidate_UnquotablePath(t *testing.T) {
+	file := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"a": {Path: "/src/a\nb"},
+			"b": {Path: "/src/it's $HOME; `id`"},
+		},
+	}
+	report := ValidateReport(file, file, map[string]GroupConfig{"default": {Prefix: "<prefix>"}})
+	var unquotable []string
+	for _, issue := range report.Issues {
+		if issue.Code == CodeUnquotablePath {
+			unquotable = append(unquotable, issue.Key)
+			if issue.Severity != SeverityError {
+				t.Errorf("unquotable path should be an error, got %+v", issue)
+			}
+		}
+	}
+	if len(unquotable) != 1 || unquotable[0] != "a" {
+		t.Errorf("unquotable paths = %v, want [a]", unquotable)
+	}
+}
+
+// TestValidateConfigChange checks that a prefix change is rejected only for
+// the conflicts it introduces.
+func TestValidateConfigChange(t *testing.T) {
+	file := &models.NavSessionsFile{
+		Sessions: map[string]models.NavSessionConfig{
+			"w": {Path: "/src/w"},
+			"x": {Path: "/src/x"},
+		},
+		Groups: map[string]models.NavGroupState{
+			"work": {Sessions: map[string]models.NavSessionConfig{"a": {Path: "/src/a"}}},
+			"play": {Sessions: map[string]models.NavSessionConfig{"b": {Path: "/src/b"}}},
+		},
+	}
+	prev := map[string]GroupConfig{
+		"default": {Prefix: "<prefix>"},
+		"work":    {Prefix: "<prefix> w"}, // already conflicts with default's w
+		"play":    {Prefix: "<prefix> p"},
+	}
+
+	moved := map[string]GroupConfig{
+		"default": prev["default"],
+		"work":    prev["work"],
+		"play":    {Prefix: "<prefix> x"},
+	}
+	report := ValidateConfigChange(file, prev, moved)
+	errs := report.Errors()
+	if len(errs) != 1 || errs[0].Code != CodePrefixConflict || errs[0].Key != "x" {
+		t.Errorf("expected only the new conflict on x as an error, got %+v", report.Issues)
+	}
+
+	unchanged := ValidateConfigChange(file, prev, prev)
+	if unchanged.HasErrors() {
+		t.Errorf("pre-existing conflicts must not reject a change, got %+v", unchanged.Issues)
+	}
+	for _, issue := range unchanged.Issues {
+		if issue.Code == CodePrefixConflict && !strings.HasSuffix(issue.Message, "(pre-existing)") {
+			t.Errorf("pre-existing conflict not marked: %+v", issue)
+		}
+	}
+}
+
+func TestValidateGroupName(t *testing.T) {
+	for name, wantErr := range map[string]bool{
+		"work":       false,
+		"client-2.x": false,
+		"":           true,
+		"default":    true,
+		"my group":   true,
+		"a/b":        true,
+	} {
+		if err := ValidateGroupName(name); (err != nil) != wantErr {
+			t.Errorf("ValidateGroupName(%q) = %v, wantErr %v", name, err, wantErr)
+		}
+	}
+}
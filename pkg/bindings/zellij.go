@@ -0,0 +1,81 @@
+package bindings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zellijGenerator renders a zellij keybinds block. Zellij has no nested
+// key tables, so only the default group is bound, inside zellij's "tmux"
+// mode (entered with Ctrl-b); other groups are listed as comments.
+// nav sessionize switches tmux sessions, and run inside zellij it would
+// start tmux in a floating pane, so sessionize and window keys are listed
+// as comments too; only popup, tui and nav actions are bound.
+type zellijGenerator struct{}
+
+func (zellijGenerator) Name() string { return "zellij" }
+
+func (zellijGenerator) Generate(groups []GroupBinding, opts Options) ([]OutputFile, error) {
+	var b strings.Builder
+	b.WriteString("// Auto-generated nav workspace bindings\n")
+	b.WriteString("// Generated by grove nav. Merge into the keybinds section of your zellij config.\n")
+	b.WriteString("// Keys that switch sessions are not bound: nav sessionize drives tmux, not zellij.\n")
+
+	var skipped []string
+	b.WriteString("keybinds {\n")
+	b.WriteString("    tmux {\n")
+	for _, group := range groups {
+		if group.Prefix == "" {
+			continue
+		}
+		if group.Name != "default" {
+			skipped = append(skipped, group.Name)
+			continue
+		}
+		if err := validateActions(group); err != nil {
+			return nil, err
+		}
+		for _, key := range sortedBoundKeys(group) {
+			if IsChord(key) {
+				fmt.Fprintf(&b, "        // %s: skipped, zellij has no key sequences\n", FormatKey(key))
+				continue
+			}
+			path := group.Sessions[key].Path
+			action := group.ActionFor(key)
+			if kind := action.EffectiveKind(); kind == ActionSessionize || kind == ActionWindow {
+				fmt.Fprintf(&b, "        // %s: %s skipped, zellij sessions cannot be switched by nav\n", FormatKey(key), kdlComment(path))
+				continue
+			}
+			run := "\"sh\" \"-c\" " + kdlString(action.ShellCommand("nav", path))
+			fmt.Fprintf(&b, "        bind %s {\n", kdlString(key))
+			fmt.Fprintf(&b, "            Run %s {\n", run)
+			b.WriteString("                floating true\n")
+			b.WriteString("                close_on_exit true\n")
+			b.WriteString("            }\n")
+			b.WriteString("            SwitchToMode \"Normal\"\n")
+			b.WriteString("        }\n")
+		}
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+
+	for _, name := range skipped {
+		fmt.Fprintf(&b, "// Group %s skipped: zellij has no nested key tables\n", name)
+	}
+
+	return []OutputFile{{
+		Path:    navOutputPath(opts, "generated-bindings.kdl"),
+		Content: []byte(b.String()),
+	}}, nil
+}
+
+// kdlComment makes s safe to write after // on a KDL line.
+func kdlComment(s string) string {
+	return strings.NewReplacer("\n", " ", "\r", " ").Replace(s)
+}
+
+// kdlString renders s as a quoted KDL string.
+func kdlString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -13,6 +13,7 @@ type ManageKeyMap struct {
 	Edit           key.Binding // Overrides Base.Edit with "map CWD" behavior
 	ViewGit        key.Binding
 	SetKey         key.Binding
+	SetChord       key.Binding // Assign a multi-key chord ("gw")
 	Open           key.Binding
 	Delete         key.Binding // Overrides Base.Delete with "clear mapping" behavior
 	MoveMode       key.Binding
@@ -74,7 +75,7 @@ func (k ManageKeyMap) Sections() []keymap.Section {
 			k.FocusCurrent,
 		),
 		keymap.SelectionSection(k.Select, k.SelectAll, k.SelectNone),
-		keymap.ActionsSection(k.Edit, k.SetKey, k.Delete, k.CopyPath),
+		keymap.ActionsSection(k.Edit, k.SetKey, k.SetChord, k.Delete, k.CopyPath),
 		keymap.NewSection("Reorder",
 			k.MoveMode, k.Lock,
 			key.NewBinding(key.WithKeys("j/k"), key.WithHelp("j/k", "move row (in move mode)")),
@@ -107,6 +108,10 @@ func NewManageKeyMap(cfg *config.Config) ManageKeyMap {
 			key.WithKeys("h"),
 			key.WithHelp("h", "set key mode"),
 		),
+		SetChord: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "assign chord"),
+		),
 		Open: key.NewBinding(
 			key.WithKeys("o", "enter"),
 			key.WithHelp("enter/o", "switch to session"),
@@ -14,6 +14,7 @@ type SessionizeKeyMap struct {
 	ViewGit              key.Binding
 	ClearKey             key.Binding
 	CloseSession         key.Binding
+	PruneIdle            key.Binding
 	FocusEcosystem       key.Binding
 	OpenEcosystem        key.Binding
 	FocusEcosystemCursor key.Binding
@@ -75,6 +76,7 @@ func (k SessionizeKeyMap) FullHelp() [][]key.Binding {
 			k.ClearKey,
 			k.CopyPath,
 			k.CloseSession,
+			k.PruneIdle,
 			k.Help,
 			k.Quit,
 		},
@@ -128,6 +130,7 @@ func (k SessionizeKeyMap) Sections() []keymap.Section {
 			k.ClearKey,
 			k.CopyPath,
 			k.CloseSession,
+			k.PruneIdle,
 		),
 		keymap.NewSection("Focus",
 			k.FocusEcosystem,
@@ -194,6 +197,10 @@ func NewSessionizeKeyMap(cfg *config.Config) SessionizeKeyMap {
 			key.WithKeys("X"),
 			key.WithHelp("X", "close session"),
 		),
+		PruneIdle: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "prune idle sessions"),
+		),
 		FocusEcosystem: key.NewBinding(
 			key.WithKeys("@"),
 			key.WithHelp("@", "focus ecosystem"),
@@ -1,13 +1,17 @@
 package tmux
 
 import (
+	"context"
 	"os/exec"
+	"time"
 
 	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/mux"
 	coretmux "github.com/grovetools/core/pkg/tmux"
 	"github.com/grovetools/core/pkg/workspace"
 
 	"github.com/grovetools/nav/internal/manager"
+	"github.com/grovetools/nav/pkg/bindings"
 )
 
 // Command creates an exec.Cmd for tmux that respects GROVE_TMUX_SOCKET.
@@ -17,6 +21,24 @@ func Command(args ...string) *exec.Cmd {
 	return coretmux.Command(args...)
 }
 
+// LaunchSpec is a standalone session description for `nav launch --file`
+type LaunchSpec = manager.LaunchSpec
+
+// ParseLaunchSpec decodes a YAML launch spec
+func ParseLaunchSpec(data []byte) (*LaunchSpec, error) {
+	return manager.ParseLaunchSpec(data)
+}
+
+// LaunchLayout creates a detached session rooted at root and builds layout in it
+func LaunchLayout(ctx context.Context, engine mux.MuxEngine, sessionName, root string, layout *manager.LayoutConfig, env map[string]string) error {
+	return manager.LaunchLayout(ctx, engine, sessionName, root, layout, env)
+}
+
+// AddLayoutWindow builds a single layout window in an existing session rooted at root
+func AddLayoutWindow(sessionName, root string, w manager.LayoutWindow) error {
+	return manager.AddLayoutWindow(sessionName, root, w)
+}
+
 // Manager manages tmux sessions and configurations
 type Manager struct {
 	mgr *manager.Manager
@@ -94,6 +116,21 @@ func (m *Manager) RecordProjectAccess(path string) error {
 	return m.mgr.RecordProjectAccess(path)
 }
 
+// WindowTemplate returns the window of that name in the layout that applies to path, or nil
+func (m *Manager) WindowTemplate(path, name string) (*manager.LayoutWindow, error) {
+	return m.mgr.WindowTemplate(path, name)
+}
+
+// RecordSessionSwitch notes the path a session name is rooted at
+func (m *Manager) RecordSessionSwitch(name, path string) error {
+	return m.mgr.RecordSessionSwitch(name, path)
+}
+
+// RecordSessionEnd records that a session was closed or detached from
+func (m *Manager) RecordSessionEnd(name, path, event string, at time.Time) error {
+	return m.mgr.RecordSessionEnd(name, path, event, at)
+}
+
 // GetAccessHistory returns the project access history
 func (m *Manager) GetAccessHistory() (*workspace.AccessHistory, error) {
 	return m.mgr.GetAccessHistory()
@@ -109,6 +146,16 @@ func (m *Manager) RegenerateBindings() error {
 	return m.mgr.RegenerateBindings()
 }
 
+// RegenerateBindingsFor regenerates bindings for the named generator targets
+func (m *Manager) RegenerateBindingsFor(targets []string) error {
+	return m.mgr.RegenerateBindingsFor(targets)
+}
+
+// PlanBindings returns the file changes regenerating targets would make
+func (m *Manager) PlanBindings(targets []string) (*bindings.Plan, error) {
+	return m.mgr.PlanBindings(targets)
+}
+
 // ReloadBindingsFromDaemon refreshes the in-process binding cache from the
 // daemon's authoritative sessions file without writing to disk. Idempotent.
 func (m *Manager) ReloadBindingsFromDaemon() error {
@@ -130,6 +177,123 @@ func (m *Manager) Sessionize(path string) error {
 	return m.mgr.Sessionize(path)
 }
 
+// LaunchSession creates a detached session for path, building its
+// configured layout (if any)
+func (m *Manager) LaunchSession(ctx context.Context, sessionName, path string) error {
+	return m.mgr.LaunchSession(ctx, sessionName, path)
+}
+
+// BindingAction returns the configured action for the project at path in a group
+func (m *Manager) BindingAction(group, path string) bindings.Action {
+	opts, _ := m.mgr.GetMappingOptions(group, path)
+	return opts.BindingAction()
+}
+
+// ResolveEnv returns the environment applied to a new session for path
+func (m *Manager) ResolveEnv(path string) (map[string]string, error) {
+	return m.mgr.ResolveEnv(path)
+}
+
+// HasSessionSetup reports whether a new session for path has a layout or environment to apply
+func (m *Manager) HasSessionSetup(path string) (bool, error) {
+	return m.mgr.HasSessionSetup(path)
+}
+
+// ListSessionInfo returns every running session annotated with its nav mapping
+func (m *Manager) ListSessionInfo() ([]manager.SessionInfo, error) {
+	return m.mgr.ListSessionInfo()
+}
+
+// FindIdleSessions returns sessions unused for at least idle, with kept ones marked by Skip
+func (m *Manager) FindIdleSessions(idle time.Duration, now time.Time) ([]manager.PruneCandidate, error) {
+	return m.mgr.FindIdleSessions(idle, now)
+}
+
+// FindStaleMappings returns mappings whose path no longer exists, in the active group or all groups
+func (m *Manager) FindStaleMappings(allGroups bool) []manager.StaleMapping {
+	return m.mgr.FindStaleMappings(allGroups)
+}
+
+// PruneStaleMappings unmaps the unlocked stale mappings and records them for UndoPrune
+func (m *Manager) PruneStaleMappings(stale []manager.StaleMapping) ([]manager.StaleMapping, error) {
+	return m.mgr.PruneStaleMappings(stale)
+}
+
+// UndoPrune restores the mappings removed by the last prune
+func (m *Manager) UndoPrune() (restored, skipped []manager.StaleMapping, err error) {
+	return m.mgr.UndoPrune()
+}
+
+// Doctor runs the tmux integration health checks behind `nav doctor`
+func (m *Manager) Doctor() []manager.DoctorCheck {
+	return m.mgr.Doctor()
+}
+
+// GroupBindings resolves every active group's prefix, icon and mappings
+func (m *Manager) GroupBindings() []bindings.GroupBinding {
+	return m.mgr.GroupBindings()
+}
+
+// ValidateBindings reports every validation issue across the active groups
+func (m *Manager) ValidateBindings() *bindings.Report {
+	return m.mgr.ValidateBindings()
+}
+
+// IdleSessions returns the names of sessions that FindIdleSessions would prune
+func (m *Manager) IdleSessions(idle time.Duration) ([]string, error) {
+	candidates, err := m.mgr.FindIdleSessions(idle, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, c := range candidates {
+		if c.Skip == "" {
+			names = append(names, c.Name)
+		}
+	}
+	return names, nil
+}
+
+// SessionName returns the session name for a project, honoring session_name_template
+func (m *Manager) SessionName(node *workspace.WorkspaceNode) string {
+	return m.mgr.SessionName(node)
+}
+
+// KnownProjectNodes returns the discovered projects session names are resolved against
+func (m *Manager) KnownProjectNodes() []*workspace.WorkspaceNode {
+	return m.mgr.KnownProjectNodes()
+}
+
+// GetSessionNameTemplate returns the configured session_name_template
+func (m *Manager) GetSessionNameTemplate() string {
+	return m.mgr.GetSessionNameTemplate()
+}
+
+// FindMappingForPath returns the group and key whose mapping points exactly at path
+func (m *Manager) FindMappingForPath(path string) (group, key string, ok bool) {
+	return m.mgr.FindMappingForPath(path)
+}
+
+// RunHooksReportingErrors runs the lifecycle hooks configured for event on a session, logging failures
+func (m *Manager) RunHooksReportingErrors(ctx context.Context, event manager.HookEvent, sessionName, path string, quiet bool) {
+	m.mgr.RunHooksReportingErrors(ctx, event, sessionName, path, quiet)
+}
+
+// PrepareHooks resolves the lifecycle hooks for event now and returns a function that runs them
+func (m *Manager) PrepareHooks(event manager.HookEvent, sessionName, path string, quiet bool) func(context.Context) {
+	return m.mgr.PrepareHooks(event, sessionName, path, quiet)
+}
+
+// IsNavSession reports whether a running session was created by nav or is rooted at a mapped project
+func (m *Manager) IsNavSession(sessionName string) bool {
+	return m.mgr.IsNavSession(sessionName)
+}
+
+// ResolveLayout returns the layout that applies to a new session for path
+func (m *Manager) ResolveLayout(path string) (*manager.LayoutConfig, error) {
+	return m.mgr.ResolveLayout(path)
+}
+
 // DetectTmuxKeyForPath detects the tmux session key for a given working directory
 func (m *Manager) DetectTmuxKeyForPath(workingDir string) string {
 	return m.mgr.DetectTmuxKeyForPath(workingDir)
@@ -140,6 +304,11 @@ func (m *Manager) GetAvailableKeys() []string {
 	return m.mgr.GetAvailableKeys()
 }
 
+// IsValidKey reports whether key is an available key or a chord of available keys
+func (m *Manager) IsValidKey(key string) bool {
+	return m.mgr.IsValidKey(key)
+}
+
 // UpdateSessionKey updates the key for a specific session
 func (m *Manager) UpdateSessionKey(oldKey, newKey string) error {
 	return m.mgr.UpdateSessionKey(oldKey, newKey)
@@ -246,6 +415,31 @@ func (m *Manager) GetGroupSessionCount(name string) int {
 	return m.mgr.GetGroupSessionCount(name)
 }
 
+// GroupInfos returns the summary of every group in display order
+func (m *Manager) GroupInfos() []manager.GroupInfo {
+	return m.mgr.GroupInfos()
+}
+
+// GroupInfo returns the summary of a group
+func (m *Manager) GroupInfo(name string) (manager.GroupInfo, bool) {
+	return m.mgr.GroupInfo(name)
+}
+
+// SetGroupIcon sets the icon of a group (default_icon for the default group)
+func (m *Manager) SetGroupIcon(name, icon string) error {
+	return m.mgr.SetGroupIcon(name, icon)
+}
+
+// MoveGroup moves a group to a 1-based position in the display order
+func (m *Manager) MoveGroup(name string, position int) error {
+	return m.mgr.MoveGroup(name, position)
+}
+
+// ValidateGroupPrefix reports the conflicts setting a group's prefix would introduce
+func (m *Manager) ValidateGroupPrefix(name, prefix string) *bindings.Report {
+	return m.mgr.ValidateGroupPrefix(name, prefix)
+}
+
 // GetDefaultIcon returns the configured icon for the default group
 func (m *Manager) GetDefaultIcon() string {
 	return m.mgr.GetDefaultIcon()
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +17,7 @@ import (
 	"github.com/grovetools/core/util/pathutil"
 
 	"github.com/grovetools/nav/pkg/api"
+	"github.com/grovetools/nav/pkg/bindings"
 )
 
 // pageStyle is the default lipgloss style for the keymanage view.
@@ -67,6 +69,15 @@ type Config struct {
 	// KeyMap lets the host override the default manage keymap. Zero
 	// value uses DefaultKeyMap().
 	KeyMap KeyMap
+
+	// SessionNameTemplate is the host's session_name_template. Empty
+	// keeps the default project identifier naming.
+	SessionNameTemplate string
+
+	// KnownProjects optionally lists the discovered projects, so session
+	// name collisions are resolved the way the host resolves them. Nil
+	// resolves them against the running sessions only.
+	KnownProjects func() []*workspace.WorkspaceNode
 }
 
 // Model is the interactive session key manager. It implements tea.Model.
@@ -103,6 +114,10 @@ type Model struct {
 	jumpMode   bool
 	setKeyMode bool
 
+	// Chord input state
+	chordMode  bool
+	chordInput string
+
 	// Move mode state
 	moveMode   bool
 	lockedKeys map[string]bool
@@ -294,7 +309,7 @@ func (m *Model) ClearPendingMapProject() { m.pendingMapProject = nil }
 // IsTextInputFocused reports whether the model is in a sub-mode that
 // captures text input (so global key bindings should be suppressed).
 func (m *Model) IsTextInputFocused() bool {
-	return m.setKeyMode || m.saveToGroupNewMode || m.newGroupMode
+	return m.setKeyMode || m.chordMode || m.saveToGroupNewMode || m.newGroupMode
 }
 
 // Sessions returns the currently-displayed sessions slice. Hosts use
@@ -545,6 +560,129 @@ func (m *Model) mapSelectedSlot() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// assignChord maps a multi-key chord typed in chord mode. A mapped row
+// under the cursor is moved to the chord; otherwise the pending project
+// (or the CWD project) is mapped to it. Every key of the chord must be one
+// of the group's single-key slots, and the chord may not share its leading
+// keys with another bound key.
+func (m *Model) assignChord(input string) (tea.Model, tea.Cmd) {
+	m.chordMode = false
+	m.chordInput = ""
+
+	steps := bindings.KeySteps(strings.TrimSpace(input))
+	if len(steps) < 2 {
+		m.message = "A chord needs at least two keys (use 'h' for single keys)"
+		return m, nil
+	}
+	chord := strings.Join(steps, "")
+	if utf8.RuneCountInString(chord) != len(steps) {
+		chord = strings.Join(steps, " ")
+	}
+
+	available := make(map[string]bool)
+	for _, s := range m.sessions {
+		if !bindings.IsChord(s.Key) {
+			available[s.Key] = true
+		}
+	}
+	for _, step := range steps {
+		if !available[step] {
+			m.message = fmt.Sprintf("'%s' is not an available key", step)
+			return m, nil
+		}
+	}
+
+	sourceIndex := -1
+	var path, name string
+	if m.pendingMapProject == nil && m.cursor < len(m.sessions) && m.sessions[m.cursor].Path != "" {
+		if m.lockedKeys[m.sessions[m.cursor].Key] {
+			m.message = "Cannot move locked key"
+			return m, nil
+		}
+		sourceIndex = m.cursor
+		path = m.sessions[m.cursor].Path
+		name = filepath.Base(path)
+	} else {
+		project := m.pendingMapProject
+		if project == nil {
+			project = m.cwdProject
+		}
+		if project == nil {
+			m.message = "Current directory is not a valid workspace/project"
+			return m, nil
+		}
+		normalized, err := pathutil.NormalizeForLookup(project.Path)
+		if err != nil {
+			m.message = "Failed to normalize project path"
+			return m, nil
+		}
+		for _, s := range m.sessions {
+			if s.Path == "" {
+				continue
+			}
+			if sNormalized, err := pathutil.NormalizeForLookup(s.Path); err == nil && sNormalized == normalized {
+				m.message = fmt.Sprintf("Project is already mapped to key '%s'", bindings.FormatKey(s.Key))
+				return m, nil
+			}
+		}
+		path = project.Path
+		name = project.Name
+		m.enrichedProjects[filepath.Clean(project.Path)] = project
+	}
+
+	var bound []string
+	for i, s := range m.sessions {
+		if i == sourceIndex || s.Path == "" {
+			continue
+		}
+		if s.Key == chord {
+			m.message = fmt.Sprintf("Chord '%s' is already mapped", bindings.FormatKey(chord))
+			return m, nil
+		}
+		bound = append(bound, s.Key)
+	}
+	if other, conflict := bindings.ChordConflict(chord, bound); conflict {
+		m.message = fmt.Sprintf("Chord '%s' is ambiguous with key '%s'", bindings.FormatKey(chord), bindings.FormatKey(other))
+		return m, nil
+	}
+
+	m.store.TakeSnapshot()
+	if sourceIndex >= 0 {
+		m.sessions[sourceIndex].Path = ""
+		m.sessions[sourceIndex].Repository = ""
+		m.sessions[sourceIndex].Description = ""
+	}
+	target := -1
+	for i, s := range m.sessions {
+		if s.Key == chord {
+			target = i
+			break
+		}
+	}
+	if target == -1 {
+		m.sessions = append(m.sessions, models.TmuxSession{Key: chord})
+		target = len(m.sessions) - 1
+	}
+	m.sessions[target].Path = path
+
+	m.rebuildSessionsOrder()
+	for i, s := range m.sessions {
+		if s.Key == chord {
+			m.cursor = i
+			break
+		}
+	}
+	m.message = fmt.Sprintf("Mapped chord '%s' to '%s'", bindings.FormatKey(chord), name)
+	m.justMappedKeys = map[string]bool{chord: true}
+	m.saveChanges()
+
+	if m.pendingMapProject != nil {
+		m.pendingMapProject = nil
+		return m, tea.Batch(clearHighlightCmd(), func() tea.Msg { return MappingDoneMsg{} })
+	}
+	return m, clearHighlightCmd()
+}
+
 // executeLoadIntoDefault performs the actual load operation after confirmation.
 func (m *Model) executeLoadIntoDefault(sourceGroup string) {
 	m.store.SetActiveGroup(sourceGroup)
@@ -847,7 +985,16 @@ func (m *Model) openSessionForPath(ctx context.Context, path string) tea.Cmd {
 		m.message = fmt.Sprintf("Failed to get project info: %v", err)
 		return nil
 	}
-	sessionName := projInfo.Identifier("_")
+	namer := api.SessionNamer{Template: m.cfg.SessionNameTemplate}
+	if lister, ok := m.driver.(api.SessionPathLister); ok {
+		namer.Running, _ = lister.ListActivePaths(ctx)
+	}
+	if m.cfg.KnownProjects != nil {
+		if projects := m.cfg.KnownProjects(); projects != nil {
+			namer = namer.WithProjects(projects)
+		}
+	}
+	sessionName := namer.Name(projInfo)
 
 	exists, err := m.driver.Exists(ctx, sessionName)
 	if err != nil {
@@ -414,6 +414,27 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Handle chord input mode.
+	if m.chordMode {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.chordMode = false
+			m.chordInput = ""
+			m.message = "Assign chord cancelled."
+		case tea.KeyEnter:
+			return m.assignChord(m.chordInput)
+		case tea.KeyBackspace:
+			if len(m.chordInput) > 0 {
+				m.chordInput = m.chordInput[:len(m.chordInput)-1]
+			}
+		case tea.KeySpace:
+			m.chordInput += " "
+		case tea.KeyRunes:
+			m.chordInput += string(msg.Runes)
+		}
+		return m, nil
+	}
+
 	// Handle set key mode.
 	if m.setKeyMode {
 		switch msg.Type {
@@ -838,6 +859,12 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.message = "Enter key or number to map CWD to. (ESC to cancel)"
 		return m, nil
 
+	case key.Matches(msg, m.keys.SetChord):
+		m.chordMode = true
+		m.chordInput = ""
+		m.message = "Type a chord (e.g. gw), Enter to assign. (ESC to cancel)"
+		return m, nil
+
 	case key.Matches(msg, m.keys.Edit):
 		return m.mapSelectedSlot()
 
@@ -11,6 +11,8 @@ import (
 	"github.com/grovetools/core/pkg/workspace"
 	"github.com/grovetools/core/tui/components/table"
 	core_theme "github.com/grovetools/core/tui/theme"
+
+	"github.com/grovetools/nav/pkg/bindings"
 )
 
 // View renders the keymanage TUI. It implements the tea.Model contract.
@@ -276,6 +278,8 @@ func (m *Model) footerLine() string {
 		modeIndicator = core_theme.DefaultTheme.Warning.Render(" [MOVE MODE]")
 	case m.setKeyMode:
 		modeIndicator = core_theme.DefaultTheme.Warning.Render(" [SET KEY MODE]")
+	case m.chordMode:
+		modeIndicator = core_theme.DefaultTheme.Warning.Render(" [CHORD: " + m.chordInput + "_]")
 	case m.saveToGroupMode:
 		modeIndicator = core_theme.DefaultTheme.Warning.Render(" [SAVE TO GROUP]")
 	case m.moveToGroupMode:
@@ -385,6 +389,13 @@ func (m *Model) buildRows(sessions []models.TmuxSession, withJustMappedHighlight
 			} else {
 				repository = filepath.Base(s.Path)
 			}
+
+			// Mark mappings to deleted worktrees or moved repos; `nav key
+			// prune` unmaps them.
+			if bindings.MissingPath(s.Path) {
+				repository = core_theme.DefaultTheme.Warning.Render(core_theme.IconWarning+" ") +
+					filepath.Base(s.Path) + dimStyle.Render(" (missing)")
+			}
 		}
 
 		branchWorktreeDisplay := worktree
@@ -406,9 +417,9 @@ func (m *Model) buildRows(sessions []models.TmuxSession, withJustMappedHighlight
 			}
 		}
 
-		keyDisplay := s.Key
+		keyDisplay := bindings.FormatKey(s.Key)
 		if withJustMappedHighlight && m.justMappedKeys[s.Key] {
-			keyDisplay = core_theme.DefaultTheme.Success.Render(core_theme.IconSuccess + " " + s.Key)
+			keyDisplay = core_theme.DefaultTheme.Success.Render(core_theme.IconSuccess + " " + keyDisplay)
 		}
 
 		selectionIndicator := fmt.Sprintf("%d", i+1)
@@ -64,6 +64,7 @@ type ProjectsUpdateMsg struct {
 // runningSessionsUpdateMsg is sent with the latest list of active sessions.
 type runningSessionsUpdateMsg struct {
 	sessions map[string]bool
+	paths    map[string]string
 }
 
 // keyMapUpdateMsg is sent when key mappings are reloaded.
@@ -429,17 +430,33 @@ func fetchAllRemoteURLsCmd(dir string, projects []*api.Project) tea.Cmd {
 func fetchRunningSessionsCmd(state SessionStateProvider) tea.Cmd {
 	return func() tea.Msg {
 		sessionsMap := make(map[string]bool)
+		var paths map[string]string
 		if state != nil {
 			ctx := context.Background()
 			names, _ := state.ListActive(ctx)
 			for _, name := range names {
 				sessionsMap[name] = true
 			}
+			paths = listActivePaths(ctx, state)
 		}
-		return runningSessionsUpdateMsg{sessions: sessionsMap}
+		return runningSessionsUpdateMsg{sessions: sessionsMap, paths: paths}
 	}
 }
 
+// listActivePaths returns the root directory of each running session when
+// the provider can report them, for session name collision handling.
+func listActivePaths(ctx context.Context, state SessionStateProvider) map[string]string {
+	lister, ok := state.(api.SessionPathLister)
+	if !ok {
+		return nil
+	}
+	paths, err := lister.ListActivePaths(ctx)
+	if err != nil {
+		return nil
+	}
+	return paths
+}
+
 // fetchKeyMapCmd reloads sessions via Store and rebuilds the path→key map.
 func fetchKeyMapCmd(store Store) tea.Cmd {
 	return func() tea.Msg {
@@ -25,6 +25,10 @@ import (
 // reference sessionizer.Features rather than importing nav/pkg/api directly.
 type Features = api.Features
 
+// defaultPruneIdle is how long a session must sit idle before the bulk
+// prune action kills it.
+const defaultPruneIdle = 6 * time.Hour
+
 // ProjectLoader fetches the full project list. The standalone nav binary
 // supplies a loader that talks to *tmux.Manager and applies the cloned-repo
 // virtual ecosystem grouping; terminal can supply its own implementation.
@@ -58,6 +62,15 @@ type Config struct {
 	// value uses DefaultKeyMap().
 	KeyMap KeyMap
 
+	// InitialFilter pre-fills the filter input (e.g. from a hotkey bound
+	// to `nav sessionize --filter`). Empty starts unfiltered.
+	InitialFilter string
+
+	// SessionNameTemplate is the host's session_name_template, used to
+	// derive session names for running-session lookups. Empty keeps the
+	// default project identifier naming.
+	SessionNameTemplate string
+
 	// DisableCx forces the CX column off regardless of persisted user
 	// state. Hosts that embed the sessionizer in long-lived processes
 	// (e.g. the terminal) use this to opt out of the cx.Manager rules
@@ -123,6 +136,8 @@ type Model struct {
 
 	keyMap          map[string]string
 	runningSessions map[string]bool
+	runningPaths    map[string]string // session name -> root dir, nil if unknown
+	namer           api.SessionNamer  // resolves name collisions among projects
 	currentSession  string
 	width           int
 	height          int
@@ -177,6 +192,10 @@ type Model struct {
 	mapToGroupCursor  int
 	mapToGroupPaths   []string
 
+	// pruneCandidates lists the idle sessions a bulk prune would kill; it
+	// is non-nil while the confirmation prompt is open.
+	pruneCandidates []string
+
 	selectedPaths map[string]bool
 
 	jumpList []jumpState
@@ -284,6 +303,29 @@ func (m *Model) scheduleDaemonReconnect() tea.Cmd {
 // and render it separately (e.g. via the pager's pinned footer slot).
 func (m *Model) SetEmbedMode(on bool) { m.embedMode = on }
 
+// sessionName returns the mux session name for a project, using the
+// host's session_name_template, and the known projects and the running
+// sessions' root dirs for collision handling.
+func (m *Model) sessionName(p *api.Project) string {
+	namer := m.namer
+	namer.Template = m.cfg.SessionNameTemplate
+	namer.Running = m.runningPaths
+	return namer.Name(p.WorkspaceNode)
+}
+
+// projectNamer returns a session namer for template that resolves
+// collisions among projects. It is rebuilt whenever the project list
+// changes, rather than on every name lookup.
+func projectNamer(template string, projects []*api.Project) api.SessionNamer {
+	nodes := make([]*workspace.WorkspaceNode, 0, len(projects))
+	for _, p := range projects {
+		if p != nil {
+			nodes = append(nodes, p.WorkspaceNode)
+		}
+	}
+	return api.SessionNamer{Template: template}.WithProjects(nodes)
+}
+
 // Selected returns the project the user chose with the Confirm key, or nil
 // if the user quit without selecting anything.
 func (m *Model) Selected() *api.Project {
@@ -320,6 +362,7 @@ func New(cfg Config, projects []*api.Project) *Model {
 	ti.Prompt = core_theme.DefaultTheme.Muted.Render("󰍉 ")
 	ti.CharLimit = 256
 	ti.Width = 50
+	ti.SetValue(cfg.InitialFilter)
 
 	store := cfg.Store
 
@@ -366,6 +409,7 @@ func New(cfg Config, projects []*api.Project) *Model {
 	}
 
 	runningSessions := make(map[string]bool)
+	var runningPaths map[string]string
 	currentSession := cfg.CurrentSession
 	if cfg.SessionStateProvider != nil {
 		ctx := context.Background()
@@ -374,6 +418,7 @@ func New(cfg Config, projects []*api.Project) *Model {
 				runningSessions[name] = true
 			}
 		}
+		runningPaths = listActivePaths(ctx, cfg.SessionStateProvider)
 	}
 
 	helpModel := help.NewBuilder().
@@ -506,6 +551,8 @@ func New(cfg Config, projects []*api.Project) *Model {
 		configDir:       cfg.ConfigDir,
 		keyMap:          keyMap,
 		runningSessions: runningSessions,
+		runningPaths:    runningPaths,
+		namer:           projectNamer(cfg.SessionNameTemplate, projects),
 		currentSession:  currentSession,
 		keys:            cfg.KeyMap,
 		availableKeys:   availableKeys,
@@ -7,6 +7,7 @@ package sessionizer
 
 import (
 	"context"
+	"time"
 
 	"github.com/grovetools/core/pkg/models"
 	"github.com/grovetools/core/pkg/workspace"
@@ -33,6 +34,14 @@ type SessionStateProvider interface {
 	Exists(ctx context.Context, sessionName string) (bool, error)
 }
 
+// IdleSessionPruner is an optional Store extension backing the bulk
+// "prune idle sessions" action. It returns the sessions that have been
+// idle for at least idle and are safe to kill. Stores that don't
+// implement it leave the action disabled.
+type IdleSessionPruner interface {
+	IdleSessions(idle time.Duration) ([]string, error)
+}
+
 // Store is the Cat 1 (selection + mutation) surface that the sessionizer
 // reads while presenting the project list. It is intentionally narrow:
 // only the methods the sessionizer model actually calls today. The nav
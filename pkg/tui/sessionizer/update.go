@@ -272,6 +272,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update the main project list and map
 		m.projects = msg.Projects
+		m.namer = projectNamer(m.cfg.SessionNameTemplate, m.projects)
 		m.projectMap = make(map[string]*api.Project, len(m.projects))
 		for _, p := range m.projects {
 			p.EnrichmentStatus = make(map[string]string)
@@ -338,6 +339,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case runningSessionsUpdateMsg:
 		// Replace the running sessions map
 		m.runningSessions = msg.sessions
+		m.runningPaths = msg.paths
 		// Re-apply filtering with updated session info
 		m.updateFiltered()
 		return m, nil
@@ -635,6 +637,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle idle prune confirmation
+		if m.pruneCandidates != nil {
+			switch {
+			case msg.Type == tea.KeyEsc, msg.String() == "n", msg.String() == "N":
+				m.pruneCandidates = nil
+				m.statusMessage = "Cancelled"
+				m.statusTimeout = time.Now().Add(2 * time.Second)
+				return m, clearStatusCmd(2 * time.Second)
+
+			case msg.String() == "y", msg.String() == "Y":
+				m.pruneIdleSessions(m.pruneCandidates)
+				m.pruneCandidates = nil
+				return m, clearStatusCmd(3 * time.Second)
+			}
+			return m, nil
+		}
+
 		// Handle new group mode
 		if m.newGroupMode {
 			switch msg.Type {
@@ -1012,7 +1031,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Close session via the configured driver + state provider.
 			if m.cursor < len(m.filtered) && m.cfg.SessionDriver != nil {
 				project := m.filtered[m.cursor]
-				sessionName := project.Identifier("_")
+				sessionName := m.sessionName(project)
 				ctx := context.Background()
 
 				exists := true
@@ -1026,7 +1045,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 						var targetSession string
 						for _, p := range m.filtered {
-							candidateName := p.Identifier("_")
+							candidateName := m.sessionName(p)
 							if candidateName == sessionName {
 								continue
 							}
@@ -1060,6 +1079,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.PruneIdle):
+			// List the sessions that have sat idle and ask before killing
+			// them; the store decides which sessions are safe (attached,
+			// locked, or busy ones are kept).
+			pruner, ok := m.store.(IdleSessionPruner)
+			if !ok || m.cfg.SessionDriver == nil {
+				m.statusMessage = "Pruning idle sessions is not supported here"
+				m.statusTimeout = time.Now().Add(2 * time.Second)
+				return m, clearStatusCmd(2 * time.Second)
+			}
+			names, err := pruner.IdleSessions(defaultPruneIdle)
+			if err != nil {
+				m.statusMessage = fmt.Sprintf("Error: %v", err)
+				m.statusTimeout = time.Now().Add(3 * time.Second)
+				return m, clearStatusCmd(3 * time.Second)
+			}
+			if len(names) == 0 {
+				m.statusMessage = fmt.Sprintf("No sessions idle for %s", defaultPruneIdle)
+				m.statusTimeout = time.Now().Add(2 * time.Second)
+				return m, clearStatusCmd(2 * time.Second)
+			}
+			m.pruneCandidates = names
+			return m, nil
+
 		case key.Matches(msg, m.keys.Help):
 			m.help.Toggle()
 			return m, nil
@@ -1778,6 +1821,21 @@ func (m *Model) clearKeyMapping(projectPath string) {
 	}
 }
 
+// pruneIdleSessions kills the idle sessions the user confirmed and reports
+// how many were killed.
+func (m *Model) pruneIdleSessions(names []string) {
+	ctx := context.Background()
+	killed := 0
+	for _, name := range names {
+		if err := m.cfg.SessionDriver.Kill(ctx, name); err == nil {
+			delete(m.runningSessions, name)
+			killed++
+		}
+	}
+	m.statusMessage = fmt.Sprintf("Pruned %d session(s) idle for %s", killed, defaultPruneIdle)
+	m.statusTimeout = time.Now().Add(3 * time.Second)
+}
+
 // executeMapToGroup moves the selected project(s) to the target group.
 // It first removes them from the source group, then maps them to available keys in the target group.
 func (m *Model) executeMapToGroup(targetGroup string) {
@@ -2059,7 +2117,7 @@ func (m *Model) updateFiltered() {
 		// the daemon pushes non-deterministically ordered project snapshots.
 		hasActive := func(path string) bool {
 			for _, p := range m.projects {
-				if (p.Path == path || p.RootEcosystemPath == path || p.ParentProjectPath == path) && m.runningSessions[p.Identifier("_")] {
+				if (p.Path == path || p.RootEcosystemPath == path || p.ParentProjectPath == path) && m.runningSessions[m.sessionName(p)] {
 					return true
 				}
 			}
@@ -2334,7 +2392,7 @@ func (m *Model) updateFiltered() {
 		var hasActive func(path string) bool
 		hasActive = func(path string) bool {
 			p := projectByPath[path]
-			if p != nil && m.runningSessions[p.Identifier("_")] {
+			if p != nil && m.runningSessions[m.sessionName(p)] {
 				return true
 			}
 			for _, child := range childrenByParent[path] {
@@ -2511,6 +2569,12 @@ func (m *Model) View() string {
 			b.WriteString("  " + prefix + g + "\n")
 		}
 		b.WriteString("  " + helpStyle.Render("j/k to select • Enter to confirm • Esc to cancel") + "\n")
+	} else if m.pruneCandidates != nil {
+		b.WriteString("  " + core_theme.DefaultTheme.Warning.Render(fmt.Sprintf("⚠ Kill %d session(s) idle for %s?", len(m.pruneCandidates), defaultPruneIdle)) + "\n")
+		for _, name := range m.pruneCandidates {
+			b.WriteString("    " + name + "\n")
+		}
+		b.WriteString("  " + helpStyle.Render("y to kill • n/Esc to cancel") + "\n")
 	} else if m.ecosystemPickerMode {
 		b.WriteString("  " + core_theme.DefaultTheme.Info.Render(core_theme.IconEcosystem+" Select ecosystem to focus") + "\n")
 	} else if m.focusedProject != nil {
@@ -2519,7 +2583,7 @@ func (m *Model) View() string {
 	}
 
 	// Status message
-	if m.statusMessage != "" && time.Now().Before(m.statusTimeout) && !m.newGroupMode && !m.mapToGroupMode {
+	if m.statusMessage != "" && time.Now().Before(m.statusTimeout) && !m.newGroupMode && !m.mapToGroupMode && m.pruneCandidates == nil {
 		b.WriteString("  " + core_theme.DefaultTheme.Success.Render(m.statusMessage) + "\n")
 	}
 
@@ -296,7 +296,7 @@ func (m *Model) formatProjectRow(project *api.Project, showCxColumn bool, taskVe
 	}
 
 	// Determine icon color based on session status
-	sessionName := project.Identifier("_")
+	sessionName := m.sessionName(project)
 	sessionExists := m.runningSessions[sessionName]
 
 	var iconStyle lipgloss.Style